@@ -0,0 +1,155 @@
+package account
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/query"
+	"app/dao/repo"
+)
+
+// DeleteHandler API router注册点
+func DeleteHandler() gin.HandlerFunc {
+	api := DeleteApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfDelete).Pointer()).Name()] = api
+	return hfDelete
+}
+
+type DeleteApi struct {
+	Info     struct{}          `name:"删除管理员" desc:"删除管理员并将其问卷、级联数据集及私有题库转移给指定管理员 仅超级管理员可用"`
+	Request  DeleteApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response DeleteApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type DeleteApiRequest struct {
+	Body struct {
+		ID         int64 `json:"id" binding:"required,gte=1" desc:"管理员ID"`
+		TransferTo int64 `json:"transfer_to" binding:"required,gte=1,nefield=ID" desc:"接收问卷的管理员ID"`
+	}
+}
+
+type DeleteApiResponse struct {
+	SurveyCount int64 `json:"survey_count" desc:"转移的问卷数量"`
+}
+
+// Run Api业务逻辑执行点
+func (d *DeleteApi) Run(ctx *gin.Context) kit.Code {
+	req := d.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限 不可删除自身
+	if admin.Type != comm.AdminTypeSuper || admin.ID == req.ID {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询待删除及接收管理员
+	record, err := repo.NewAdminRepo().FindByID(ctx, req.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员失败")
+		return comm.CodeDatabaseError
+	}
+	if record == nil {
+		return comm.CodeAdminNotExist
+	}
+	receiver, err := repo.NewAdminRepo().FindByID(ctx, req.TransferTo)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询接收管理员失败")
+		return comm.CodeDatabaseError
+	}
+	if receiver == nil || comm.AdminStatus(receiver.Status) != comm.AdminStatusNormal {
+		return comm.CodeAdminNotExist
+	}
+
+	// 事务 转移问卷、级联数据集及私有题库 -> 删除管理员 -> 记录审计日志
+	err = repo.Transaction(func(tx *query.Query) error {
+		count, err := repo.NewSurveyRepo(tx).TransferAdmin(ctx, record.ID, receiver.ID)
+		if err != nil {
+			return err
+		}
+		d.Response.SurveyCount = count
+
+		if _, err := repo.NewCascadeDatasetRepo(tx).TransferAdmin(ctx, record.ID, receiver.ID); err != nil {
+			return err
+		}
+		if _, err := repo.NewQuestionBankRepo(tx).TransferAdmin(ctx, record.ID, receiver.ID); err != nil {
+			return err
+		}
+
+		if _, err := repo.NewAdminRecoveryCodeRepo(tx).DeleteByAdminID(ctx, record.ID); err != nil {
+			return err
+		}
+
+		if _, err := repo.NewAdminRepo(tx).DeleteByID(ctx, record.ID); err != nil {
+			return err
+		}
+
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionAdminDelete,
+			TargetType: comm.AuditTargetAdmin,
+			TargetID:   record.ID,
+			Detail: map[string]any{
+				"username":     record.Username,
+				"transfer_to":  receiver.ID,
+				"survey_count": count,
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("删除管理员失败")
+		return comm.CodeDatabaseError
+	}
+
+	// 更新账号状态缓存 使已签发的Token立即失效
+	if err := cache.NewAdminStatusCache().Set(ctx, record.ID, 0); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("更新管理员状态缓存失败")
+		return comm.CodeRedisError
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (d *DeleteApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&d.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfDelete API执行入口
+func hfDelete(ctx *gin.Context) {
+	api := &DeleteApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package account
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/query"
+	"app/dao/repo"
+)
+
+// EnforceHandler API router注册点
+func EnforceHandler() gin.HandlerFunc {
+	api := EnforceApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfEnforce).Pointer()).Name()] = api
+	return hfEnforce
+}
+
+type EnforceApi struct {
+	Info     struct{}           `name:"设置强制二次验证" desc:"开启后未通过二次验证的管理员会话仅可访问登录及二次验证相关接口 仅超级管理员可用"`
+	Request  EnforceApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response EnforceApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type EnforceApiRequest struct {
+	Body struct {
+		Enforced bool `json:"enforced" desc:"是否强制全部管理员启用二次验证"`
+	}
+}
+
+type EnforceApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (e *EnforceApi) Run(ctx *gin.Context) kit.Code {
+	req := e.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限 开启前需自身已通过二次验证 避免锁定自身
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+	if req.Enforced && !admin.TwoFactor {
+		return comm.CodeTotpSetupRequired
+	}
+
+	// 事务 保存配置 -> 记录审计日志
+	value := strconv.FormatBool(req.Enforced)
+	err = repo.Transaction(func(tx *query.Query) error {
+		if err := repo.NewSystemSettingRepo(tx).Save(ctx, comm.SettingTotpEnforced, value); err != nil {
+			return err
+		}
+
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionTotpEnforce,
+			TargetType: comm.AuditTargetSystem,
+			Detail: map[string]any{
+				"enforced": req.Enforced,
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("保存系统配置失败")
+		return comm.CodeDatabaseError
+	}
+
+	// 更新系统配置缓存
+	if err := cache.NewSettingCache().Set(ctx, comm.SettingTotpEnforced, value); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("更新系统配置缓存失败")
+		return comm.CodeRedisError
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (e *EnforceApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&e.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfEnforce API执行入口
+func hfEnforce(ctx *gin.Context) {
+	api := &EnforceApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package account
+
+import (
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/repo"
+)
+
+// ListHandler API router注册点
+func ListHandler() gin.HandlerFunc {
+	api := ListApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfList).Pointer()).Name()] = api
+	return hfList
+}
+
+type ListApi struct {
+	Info     struct{}        `name:"获取管理员列表" desc:"获取管理员列表及各管理员问卷数量 仅超级管理员可用"`
+	Request  ListApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response ListApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type ListApiRequest struct {
+	Query struct {
+		Page     int              `form:"page" binding:"required,gte=1" desc:"页码"`
+		PageSize int              `form:"page_size" binding:"required,gte=1,lte=100" desc:"每页数量"`
+		Status   comm.AdminStatus `form:"status" binding:"omitempty,oneof=1 2" desc:"状态 1-正常 2-禁用"`
+		Keyword  string           `form:"keyword" binding:"omitempty,max=16" desc:"用户名关键词"`
+	}
+}
+
+type ListApiResponse struct {
+	Page     int         `json:"page" desc:"页码"`
+	PageSize int         `json:"page_size" desc:"每页数量"`
+	List     []AdminItem `json:"list" desc:"管理员列表"`
+	Total    int64       `json:"total" desc:"总数量"`
+}
+
+type AdminItem struct {
+	ID          int64            `json:"id" desc:"管理员ID"`
+	Username    string           `json:"username" desc:"用户名"`
+	Type        comm.AdminType   `json:"type" desc:"类型 1-普通管理员 2-超级管理员"`
+	Status      comm.AdminStatus `json:"status" desc:"状态 1-正常 2-禁用"`
+	TotpEnabled bool             `json:"totp_enabled" desc:"是否已启用二次验证"`
+	SurveyCount int64            `json:"survey_count" desc:"问卷数量"`
+	CreatedAt   string           `json:"created_at" desc:"创建时间"`
+}
+
+// Run Api业务逻辑执行点
+func (l *ListApi) Run(ctx *gin.Context) kit.Code {
+	req := l.Request.Query
+	l.Response.Page = req.Page
+	l.Response.PageSize = req.PageSize
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询管理员列表
+	list, total, err := repo.NewAdminRepo().FindPage(ctx, req.Page, req.PageSize, req.Status, req.Keyword)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员列表失败")
+		return comm.CodeDatabaseError
+	}
+	l.Response.Total = total
+
+	// 查询问卷数量
+	countMap := make(map[int64]int64)
+	if len(list) > 0 {
+		adminIDs := lo.Map(list, func(item *model.Admin, _ int) int64 {
+			return item.ID
+		})
+		counts, err := repo.NewSurveyRepo().CountGroupByAdminIDs(ctx, adminIDs)
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷数量失败")
+			return comm.CodeDatabaseError
+		}
+		countMap = lo.SliceToMap(counts, func(item repo.AdminSurveyCount) (int64, int64) {
+			return item.AdminID, item.Count
+		})
+	}
+
+	// 构建响应数据
+	l.Response.List = lo.Map(list, func(item *model.Admin, _ int) AdminItem {
+		return AdminItem{
+			ID:          item.ID,
+			Username:    item.Username,
+			Type:        comm.AdminType(item.Type),
+			Status:      comm.AdminStatus(item.Status),
+			TotpEnabled: item.TotpSecret != "",
+			SurveyCount: countMap[item.ID],
+			CreatedAt:   item.CreatedAt.Format(time.DateTime),
+		}
+	})
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (l *ListApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&l.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfList API执行入口
+func hfList(ctx *gin.Context) {
+	api := &ListApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package account
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/query"
+	"app/dao/repo"
+)
+
+// PasswordHandler API router注册点
+func PasswordHandler() gin.HandlerFunc {
+	api := PasswordApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfPassword).Pointer()).Name()] = api
+	return hfPassword
+}
+
+type PasswordApi struct {
+	Info     struct{}            `name:"重置管理员密码" desc:"重置指定管理员的密码 仅超级管理员可用"`
+	Request  PasswordApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response PasswordApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type PasswordApiRequest struct {
+	Body struct {
+		ID       int64  `json:"id" binding:"required,gte=1" desc:"管理员ID"`
+		Password string `json:"password" binding:"required,max=32" desc:"新密码"`
+	}
+}
+
+type PasswordApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (p *PasswordApi) Run(ctx *gin.Context) kit.Code {
+	req := p.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询管理员
+	record, err := repo.NewAdminRepo().FindByID(ctx, req.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员失败")
+		return comm.CodeDatabaseError
+	}
+	if record == nil {
+		return comm.CodeAdminNotExist
+	}
+
+	// 密码加密
+	password, err := comm.HashPassword(req.Password)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("密码加密失败")
+		return comm.CodeUnknownError
+	}
+
+	// 事务 更新密码 -> 记录审计日志
+	err = repo.Transaction(func(tx *query.Query) error {
+		if _, err := repo.NewAdminRepo(tx).UpdatePassword(ctx, record.ID, password); err != nil {
+			return err
+		}
+
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionAdminPassword,
+			TargetType: comm.AuditTargetAdmin,
+			TargetID:   record.ID,
+			Detail: map[string]any{
+				"username": record.Username,
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("重置管理员密码失败")
+		return comm.CodeDatabaseError
+	}
+
+	// 吊销全部会话 使用旧密码登录的Token立即失效
+	if err := cache.NewSessionCache(cache.SessionScopeAdmin).RevokeAll(ctx, strconv.FormatInt(record.ID, 10)); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("吊销全部会话失败")
+		return comm.CodeRedisError
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (p *PasswordApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&p.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfPassword API执行入口
+func hfPassword(ctx *gin.Context) {
+	api := &PasswordApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package account
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/repo"
+)
+
+// RevokeHandler API router注册点
+func RevokeHandler() gin.HandlerFunc {
+	api := RevokeApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfRevoke).Pointer()).Name()] = api
+	return hfRevoke
+}
+
+type RevokeApi struct {
+	Info     struct{}          `name:"吊销全部会话" desc:"吊销指定管理员或用户当前已签发的全部访问Token及刷新Token 仅超级管理员可用"`
+	Request  RevokeApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response RevokeApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type RevokeApiRequest struct {
+	Body struct {
+		Scope    string `json:"scope" binding:"required,oneof=admin user" desc:"会话类型 admin:管理员 user:用户"`
+		AdminID  int64  `json:"admin_id" binding:"required_if=Scope admin,omitempty,gte=1" desc:"管理员ID scope=admin时生效"`
+		Username string `json:"username" binding:"required_if=Scope user,omitempty,max=16" desc:"用户名 scope=user时生效"`
+	}
+}
+
+type RevokeApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (r *RevokeApi) Run(ctx *gin.Context) kit.Code {
+	req := r.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	subject := req.Username
+	targetType, targetID := comm.AuditTargetUser, int64(0)
+	if req.Scope == cache.SessionScopeAdmin {
+		// 查询管理员
+		record, err := repo.NewAdminRepo().FindByID(ctx, req.AdminID)
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员失败")
+			return comm.CodeDatabaseError
+		}
+		if record == nil {
+			return comm.CodeAdminNotExist
+		}
+		subject = strconv.FormatInt(record.ID, 10)
+		targetType, targetID = comm.AuditTargetAdmin, record.ID
+	}
+
+	// 吊销全部会话
+	if err := cache.NewSessionCache(req.Scope).RevokeAll(ctx, subject); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("吊销全部会话失败")
+		return comm.CodeRedisError
+	}
+
+	// 记录审计日志 会话已吊销 记录失败不影响吊销结果
+	err = repo.NewAuditLogRepo().Record(ctx, repo.AuditEntry{
+		AdminID:    admin.ID,
+		Action:     comm.AuditActionSessionRevoke,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Detail: map[string]any{
+			"scope":    req.Scope,
+			"username": req.Username,
+		},
+		IP:        ctx.ClientIP(),
+		RequestID: requestid.Get(ctx),
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("记录审计日志失败")
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (r *RevokeApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&r.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfRevoke API执行入口
+func hfRevoke(ctx *gin.Context) {
+	api := &RevokeApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package account
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/query"
+	"app/dao/repo"
+)
+
+// StatusHandler API router注册点
+func StatusHandler() gin.HandlerFunc {
+	api := StatusApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfStatus).Pointer()).Name()] = api
+	return hfStatus
+}
+
+type StatusApi struct {
+	Info     struct{}          `name:"修改管理员状态" desc:"启用或禁用管理员 禁用后该管理员已签发的Token立即失效 仅超级管理员可用"`
+	Request  StatusApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response StatusApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type StatusApiRequest struct {
+	Body struct {
+		ID     int64            `json:"id" binding:"required,gte=1" desc:"管理员ID"`
+		Status comm.AdminStatus `json:"status" binding:"required,oneof=1 2" desc:"状态 1-正常 2-禁用"`
+	}
+}
+
+type StatusApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (s *StatusApi) Run(ctx *gin.Context) kit.Code {
+	req := s.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限 不可修改自身状态
+	if admin.Type != comm.AdminTypeSuper || admin.ID == req.ID {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询管理员
+	record, err := repo.NewAdminRepo().FindByID(ctx, req.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员失败")
+		return comm.CodeDatabaseError
+	}
+	if record == nil {
+		return comm.CodeAdminNotExist
+	}
+
+	// 事务 修改状态 -> 记录审计日志
+	err = repo.Transaction(func(tx *query.Query) error {
+		if _, err := repo.NewAdminRepo(tx).UpdateStatus(ctx, record.ID, req.Status); err != nil {
+			return err
+		}
+
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionAdminStatus,
+			TargetType: comm.AuditTargetAdmin,
+			TargetID:   record.ID,
+			Detail: map[string]any{
+				"username": record.Username,
+				"before":   record.Status,
+				"after":    req.Status,
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("修改管理员状态失败")
+		return comm.CodeDatabaseError
+	}
+
+	// 更新账号状态缓存
+	if err := cache.NewAdminStatusCache().Set(ctx, record.ID, req.Status); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("更新管理员状态缓存失败")
+		return comm.CodeRedisError
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (s *StatusApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&s.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfStatus API执行入口
+func hfStatus(ctx *gin.Context) {
+	api := &StatusApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package audit
+
+import (
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/repo"
+)
+
+// ListHandler API router注册点
+func ListHandler() gin.HandlerFunc {
+	api := ListApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfList).Pointer()).Name()] = api
+	return hfList
+}
+
+type ListApi struct {
+	Info     struct{}        `name:"获取审计日志列表" desc:"按条件查询管理员操作审计日志 仅超级管理员可用"`
+	Request  ListApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response ListApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type ListApiRequest struct {
+	Query struct {
+		Page       int              `form:"page" binding:"required,gte=1" desc:"页码"`
+		PageSize   int              `form:"page_size" binding:"required,gte=1,lte=100" desc:"每页数量"`
+		AdminID    int64            `form:"admin_id" binding:"omitempty,gte=1" desc:"操作管理员ID"`
+		Action     comm.AuditAction `form:"action" binding:"omitempty,oneof=survey.create survey.update survey.status survey.delete survey.freeze result.export result.reveal result.retention result.archive result.restore privacy.export privacy.erase access_code.create access_code.export allowlist.save admin.status admin.password admin.delete session.revoke invite.create invite.expire system.maintenance system.totp_enforce" desc:"操作"`
+		TargetType comm.AuditTarget `form:"target_type" binding:"omitempty,oneof=survey user system admin invite" desc:"操作对象类型"`
+		TargetID   int64            `form:"target_id" binding:"omitempty,gte=1" desc:"操作对象ID"`
+		BeginTime  string           `form:"begin_time" binding:"omitempty,datetime=2006-01-02 15:04:05" desc:"开始时间"`
+		EndTime    string           `form:"end_time" binding:"omitempty,datetime=2006-01-02 15:04:05" desc:"结束时间"`
+	}
+}
+
+type ListApiResponse struct {
+	Page     int       `json:"page" desc:"页码"`
+	PageSize int       `json:"page_size" desc:"每页数量"`
+	List     []LogItem `json:"list" desc:"审计日志列表"`
+	Total    int64     `json:"total" desc:"总数量"`
+}
+
+type LogItem struct {
+	ID         int64            `json:"id" desc:"日志ID"`
+	AdminID    int64            `json:"admin_id" desc:"操作管理员ID"`
+	AdminName  string           `json:"admin_name" desc:"操作管理员用户名 管理员已删除时为空"`
+	Action     comm.AuditAction `json:"action" desc:"操作"`
+	TargetType comm.AuditTarget `json:"target_type" desc:"操作对象类型"`
+	TargetID   int64            `json:"target_id" desc:"操作对象ID"`
+	Detail     map[string]any   `json:"detail" desc:"操作详情 更新问卷时包含结构变更摘要diff"`
+	IP         string           `json:"ip" desc:"客户端IP"`
+	RequestID  string           `json:"request_id" desc:"请求ID"`
+	CreatedAt  string           `json:"created_at" desc:"操作时间"`
+}
+
+// Run Api业务逻辑执行点
+func (l *ListApi) Run(ctx *gin.Context) kit.Code {
+	req := l.Request.Query
+	l.Response.Page = req.Page
+	l.Response.PageSize = req.PageSize
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询审计日志列表
+	filter := repo.AuditFilter{
+		AdminID:    req.AdminID,
+		Action:     req.Action,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+	}
+	if req.BeginTime != "" {
+		filter.BeginTime, _ = time.ParseInLocation(time.DateTime, req.BeginTime, time.Local)
+	}
+	if req.EndTime != "" {
+		filter.EndTime, _ = time.ParseInLocation(time.DateTime, req.EndTime, time.Local)
+	}
+	list, total, err := repo.NewAuditLogRepo().FindPage(ctx, req.Page, req.PageSize, filter)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询审计日志列表失败")
+		return comm.CodeDatabaseError
+	}
+	l.Response.Total = total
+
+	// 查询操作管理员
+	adminMap := make(map[int64]string)
+	if len(list) > 0 {
+		adminIDs := lo.Uniq(lo.Map(list, func(item *model.AuditLog, _ int) int64 {
+			return item.AdminID
+		}))
+		admins, err := repo.NewAdminRepo().FindListByIDs(ctx, adminIDs)
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员列表失败")
+			return comm.CodeDatabaseError
+		}
+		adminMap = lo.SliceToMap(admins, func(item *model.Admin) (int64, string) {
+			return item.ID, item.Username
+		})
+	}
+
+	// 构建响应数据
+	l.Response.List = lo.Map(list, func(item *model.AuditLog, _ int) LogItem {
+		var detail map[string]any
+		if err := sonic.UnmarshalString(item.Detail, &detail); err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Warnf("审计日志详情反序列化失败 ID:%d", item.ID)
+		}
+		return LogItem{
+			ID:         item.ID,
+			AdminID:    item.AdminID,
+			AdminName:  adminMap[item.AdminID],
+			Action:     comm.AuditAction(item.Action),
+			TargetType: comm.AuditTarget(item.TargetType),
+			TargetID:   item.TargetID,
+			Detail:     detail,
+			IP:         item.IP,
+			RequestID:  item.RequestID,
+			CreatedAt:  item.CreatedAt.Format(time.DateTime),
+		}
+	})
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (l *ListApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&l.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfList API执行入口
+func hfList(ctx *gin.Context) {
+	api := &ListApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
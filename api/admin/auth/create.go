@@ -1,8 +1,11 @@
 package auth
 
 import (
+	"crypto/subtle"
+	"errors"
 	"reflect"
 	"runtime"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/zjutjh/mygo/foundation/reply"
@@ -13,9 +16,13 @@ import (
 
 	"app/comm"
 	"app/dao/model"
+	"app/dao/query"
 	"app/dao/repo"
 )
 
+// errInviteUnavailable 邀请码在校验后被并发用尽或过期
+var errInviteUnavailable = errors.New("邀请码已失效")
+
 // CreateHandler API router注册点
 func CreateHandler() gin.HandlerFunc {
 	api := CreateApi{}
@@ -24,7 +31,7 @@ func CreateHandler() gin.HandlerFunc {
 }
 
 type CreateApi struct {
-	Info     struct{}          `name:"创建管理员" desc:"仅能创建普通管理员"`
+	Info     struct{}          `name:"创建管理员" desc:"仅能创建普通管理员 超管登录时直接创建 否则需使用邀请码"`
 	Request  CreateApiRequest  // API请求参数 (Uri/Header/Query/Body)
 	Response CreateApiResponse // API响应数据 (Body中的Data部分)
 }
@@ -33,7 +40,8 @@ type CreateApiRequest struct {
 	Body struct {
 		Username string `json:"username" binding:"required,max=16" desc:"用户名"`
 		Password string `json:"password" binding:"required,max=32" desc:"密码"`
-		Secret   string `json:"secret" binding:"max=64" desc:"密钥 超管登录可忽略"`
+		Invite   string `json:"invite" binding:"max=32" desc:"邀请码 超管登录可忽略"`
+		Secret   string `json:"secret" binding:"max=64" desc:"创建管理员密钥 仅在开启初始化创建时生效 超管登录可忽略"`
 	}
 }
 
@@ -43,12 +51,31 @@ type CreateApiResponse struct{}
 func (c *CreateApi) Run(ctx *gin.Context) kit.Code {
 	req := c.Request.Body
 
-	// 获取登录管理员信息
+	// 获取登录管理员信息 超管登录时直接创建
+	var invite *model.AdminInvite
 	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
 	if err != nil || admin.Type != comm.AdminTypeSuper {
-		// 非超管登录 校验密钥
-		if req.Secret != comm.BizConf.AdminCreateSecret {
-			nlog.Pick().WithContext(ctx).Warn("创建管理员密钥错误")
+		switch {
+		case req.Invite != "":
+			// 校验邀请码
+			invite, err = repo.NewAdminInviteRepo().FindByCode(ctx, req.Invite)
+			if err != nil {
+				nlog.Pick().WithContext(ctx).WithError(err).Error("查询邀请码失败")
+				return comm.CodeDatabaseError
+			}
+			if invite == nil || invite.UsedCount >= invite.MaxUses || !invite.ExpireAt.After(time.Now()) {
+				return comm.CodeInviteInvalid
+			}
+			if invite.Username != "" && invite.Username != req.Username {
+				return comm.CodeInviteMismatch
+			}
+		case comm.BizConf.AdminBootstrap && comm.BizConf.AdminCreateSecret != "":
+			// 初始化部署 校验密钥
+			if subtle.ConstantTimeCompare([]byte(req.Secret), []byte(comm.BizConf.AdminCreateSecret)) != 1 {
+				nlog.Pick().WithContext(ctx).Warn("创建管理员密钥错误")
+				return comm.CodePermissionDenied
+			}
+		default:
 			return comm.CodePermissionDenied
 		}
 	}
@@ -70,12 +97,40 @@ func (c *CreateApi) Run(ctx *gin.Context) kit.Code {
 		return comm.CodeUnknownError
 	}
 
-	// 创建管理员
-	if err := repo.NewAdminRepo().Create(ctx, &model.Admin{
+	// 事务 使用邀请码 -> 创建管理员 -> 记录邀请码使用
+	newAdmin := &model.Admin{
 		Username: req.Username,
 		Password: password,
 		Type:     int8(comm.AdminTypeNormal),
-	}); err != nil {
+	}
+	err = repo.Transaction(func(tx *query.Query) error {
+		if invite != nil {
+			rows, err := repo.NewAdminInviteRepo(tx).Consume(ctx, invite.ID, time.Now())
+			if err != nil {
+				return err
+			}
+			if rows == 0 {
+				return errInviteUnavailable
+			}
+		}
+
+		if err := repo.NewAdminRepo(tx).Create(ctx, newAdmin); err != nil {
+			return err
+		}
+
+		if invite != nil {
+			return repo.NewAdminInviteUseRepo(tx).Create(ctx, &model.AdminInviteUse{
+				InviteID: invite.ID,
+				AdminID:  newAdmin.ID,
+				Username: newAdmin.Username,
+			})
+		}
+		return nil
+	})
+	if errors.Is(err, errInviteUnavailable) {
+		return comm.CodeInviteInvalid
+	}
+	if err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("创建管理员失败")
 		return comm.CodeDatabaseError
 	}
@@ -31,8 +31,9 @@ type InfoApiRequest struct {
 }
 
 type InfoApiResponse struct {
-	Username string         `json:"username" desc:"用户名"`
-	Type     comm.AdminType `json:"type" desc:"用户类型"`
+	Username  string         `json:"username" desc:"用户名"`
+	Type      comm.AdminType `json:"type" desc:"用户类型"`
+	TwoFactor bool           `json:"two_factor" desc:"本次会话是否已通过二次验证"`
 }
 
 // Run Api业务逻辑执行点
@@ -44,6 +45,7 @@ func (i *InfoApi) Run(ctx *gin.Context) kit.Code {
 	}
 	i.Response.Username = admin.Username
 	i.Response.Type = admin.Type
+	i.Response.TwoFactor = admin.TwoFactor
 
 	return comm.CodeOK
 }
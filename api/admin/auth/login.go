@@ -6,12 +6,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/zjutjh/mygo/foundation/reply"
-	"github.com/zjutjh/mygo/jwt"
 	"github.com/zjutjh/mygo/kit"
 	"github.com/zjutjh/mygo/nlog"
 	"github.com/zjutjh/mygo/swagger"
 
 	"app/comm"
+	"app/dao/cache"
 	"app/dao/repo"
 )
 
@@ -32,43 +32,88 @@ type LoginApiRequest struct {
 	Body struct {
 		Username string `json:"username" binding:"required,max=16" desc:"用户名"`
 		Password string `json:"password" binding:"required,max=32" desc:"密码"`
+		Code     string `json:"code" binding:"max=32" desc:"二次验证码或恢复码 已启用二次验证时必填"`
 	}
 }
 
 type LoginApiResponse struct {
-	Token string `json:"token" desc:"token"`
+	Token        string `json:"token" desc:"访问Token"`
+	RefreshToken string `json:"refresh_token" desc:"刷新Token 访问Token过期后用于续期 仅可使用一次"`
 }
 
 // Run Api业务逻辑执行点
 func (l *LoginApi) Run(ctx *gin.Context) kit.Code {
 	req := l.Request.Body
 
+	// 检查登录尝试限制
+	guard := cache.NewLoginGuardCache(cache.LoginGuardScopeAdmin)
+	wait, err := guard.Wait(ctx, req.Username, ctx.ClientIP())
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询登录尝试限制失败")
+		return comm.CodeRedisError
+	}
+	if wait > 0 {
+		return comm.CodeLoginLimited
+	}
+
 	// 查询管理员
 	admin, err := repo.NewAdminRepo().FindByUsername(ctx, req.Username)
 	if err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员失败")
 		return comm.CodeDatabaseError
 	}
+
+	// 校验密码 管理员不存在与密码错误统一响应
 	if admin == nil {
-		return comm.CodeAdminNotExist
+		comm.CompareDummyPassword(req.Password)
+	}
+	if admin == nil || comm.ComparePassword(admin.Password, req.Password) != nil {
+		if err := guard.Fail(ctx, req.Username, ctx.ClientIP()); err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("记录登录失败次数失败")
+		}
+		return comm.CodeInvalidCredentials
 	}
 
-	// 校验密码
-	if err := comm.ComparePassword(admin.Password, req.Password); err != nil {
-		return comm.CodeAdminPasswordError
+	// 检查账号状态
+	if comm.AdminStatus(admin.Status) != comm.AdminStatusNormal {
+		return comm.CodeAdminDisabled
 	}
 
-	// 生成 Token
-	token, err := jwt.Pick[comm.AdminIdentity]("jwt_admin").GenerateToken(comm.AdminIdentity{
-		ID:       admin.ID,
-		Username: admin.Username,
-		Type:     comm.AdminType(admin.Type),
+	// 校验二次验证
+	twoFactor := admin.TotpSecret != ""
+	if twoFactor {
+		if req.Code == "" {
+			return comm.CodeTotpRequired
+		}
+		ok, err := verifySecondFactor(ctx, admin, req.Code)
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("校验二次验证码失败")
+			return comm.CodeDatabaseError
+		}
+		if !ok {
+			if err := guard.Fail(ctx, req.Username, ctx.ClientIP()); err != nil {
+				nlog.Pick().WithContext(ctx).WithError(err).Error("记录登录失败次数失败")
+			}
+			return comm.CodeTotpInvalid
+		}
+	}
+
+	if err := guard.Reset(ctx, req.Username); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("清除登录失败次数失败")
+	}
+
+	// 签发 Token
+	token, refreshToken, code := issueToken(ctx, comm.AdminIdentity{
+		ID:        admin.ID,
+		Username:  admin.Username,
+		Type:      comm.AdminType(admin.Type),
+		TwoFactor: twoFactor,
 	})
-	if err != nil {
-		nlog.Pick().WithContext(ctx).WithError(err).Error("生成 Token 失败")
-		return comm.CodeUnknownError
+	if code != comm.CodeOK {
+		return code
 	}
 	l.Response.Token = token
+	l.Response.RefreshToken = refreshToken
 
 	return comm.CodeOK
 }
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+)
+
+// LogoutHandler API router注册点
+func LogoutHandler() gin.HandlerFunc {
+	api := LogoutApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfLogout).Pointer()).Name()] = api
+	return hfLogout
+}
+
+type LogoutApi struct {
+	Info     struct{}          `name:"管理员登出" desc:"吊销当前访问Token及传入的刷新Token"`
+	Request  LogoutApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response LogoutApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type LogoutApiRequest struct {
+	Body struct {
+		RefreshToken string `json:"refresh_token" binding:"omitempty,max=64" desc:"刷新Token 传入时一并吊销"`
+	}
+}
+
+type LogoutApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (l *LogoutApi) Run(ctx *gin.Context) kit.Code {
+	req := l.Request.Body
+	sessionCache := cache.NewSessionCache(cache.SessionScopeAdmin)
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 吊销访问Token
+	if err := sessionCache.Deny(ctx, admin.Session()); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("吊销访问 Token 失败")
+		return comm.CodeRedisError
+	}
+
+	// 吊销刷新Token
+	if req.RefreshToken != "" {
+		if err := sessionCache.DelRefresh(ctx, req.RefreshToken); err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("吊销刷新 Token 失败")
+			return comm.CodeRedisError
+		}
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (l *LogoutApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&l.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfLogout API执行入口
+func hfLogout(ctx *gin.Context) {
+	api := &LogoutApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
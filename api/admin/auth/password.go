@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/repo"
+)
+
+// PasswordHandler API router注册点
+func PasswordHandler() gin.HandlerFunc {
+	api := PasswordApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfPassword).Pointer()).Name()] = api
+	return hfPassword
+}
+
+type PasswordApi struct {
+	Info     struct{}            `name:"修改密码" desc:"修改当前登录管理员的密码"`
+	Request  PasswordApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response PasswordApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type PasswordApiRequest struct {
+	Body struct {
+		OldPassword string `json:"old_password" binding:"required,max=32" desc:"原密码"`
+		NewPassword string `json:"new_password" binding:"required,max=32,nefield=OldPassword" desc:"新密码"`
+	}
+}
+
+type PasswordApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (p *PasswordApi) Run(ctx *gin.Context) kit.Code {
+	req := p.Request.Body
+
+	// 获取登录管理员信息
+	identity, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询管理员
+	admin, err := repo.NewAdminRepo().FindByID(ctx, identity.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员失败")
+		return comm.CodeDatabaseError
+	}
+	if admin == nil {
+		return comm.CodeAdminNotExist
+	}
+
+	// 校验原密码
+	if err := comm.ComparePassword(admin.Password, req.OldPassword); err != nil {
+		return comm.CodeAdminPasswordError
+	}
+
+	// 密码加密
+	password, err := comm.HashPassword(req.NewPassword)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("密码加密失败")
+		return comm.CodeUnknownError
+	}
+
+	// 更新密码
+	if _, err := repo.NewAdminRepo().UpdatePassword(ctx, admin.ID, password); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("修改密码失败")
+		return comm.CodeDatabaseError
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (p *PasswordApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&p.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfPassword API执行入口
+func hfPassword(ctx *gin.Context) {
+	api := &PasswordApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
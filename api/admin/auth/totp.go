@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/model"
+	"app/dao/repo"
+)
+
+// verifySecondFactor 校验TOTP验证码或恢复码 同一验证码及恢复码仅可使用一次
+func verifySecondFactor(ctx *gin.Context, admin *model.Admin, code string) (bool, error) {
+	// 未加密的历史密钥原样返回
+	secret, err := comm.AnswerKeyring.Decrypt(admin.TotpSecret)
+	if err != nil {
+		return false, err
+	}
+	if step, ok := comm.VerifyTotp(secret, code, time.Now()); ok {
+		return cache.NewTotpCache().Use(ctx, admin.ID, step)
+	}
+
+	rows, err := repo.NewAdminRecoveryCodeRepo().Consume(ctx, admin.ID, comm.HashRecoveryCode(code), time.Now().UnixMilli())
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/query"
+	"app/dao/repo"
+)
+
+// TotpDisableHandler API router注册点
+func TotpDisableHandler() gin.HandlerFunc {
+	api := TotpDisableApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfTotpDisable).Pointer()).Name()] = api
+	return hfTotpDisable
+}
+
+type TotpDisableApi struct {
+	Info     struct{}               `name:"停用二次验证" desc:"校验密码及二次验证码后停用二次验证 强制二次验证开启时不可停用"`
+	Request  TotpDisableApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response TotpDisableApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type TotpDisableApiRequest struct {
+	Body struct {
+		Password string `json:"password" binding:"required,max=32" desc:"密码"`
+		Code     string `json:"code" binding:"required,max=32" desc:"二次验证码或恢复码"`
+	}
+}
+
+type TotpDisableApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (t *TotpDisableApi) Run(ctx *gin.Context) kit.Code {
+	req := t.Request.Body
+
+	// 获取登录管理员信息
+	identity, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询管理员
+	admin, err := repo.NewAdminRepo().FindByID(ctx, identity.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员失败")
+		return comm.CodeDatabaseError
+	}
+	if admin == nil {
+		return comm.CodeAdminNotExist
+	}
+	if admin.TotpSecret == "" {
+		return comm.CodeTotpNotEnabled
+	}
+
+	// 强制二次验证时不可停用
+	setting, err := repo.NewSystemSettingRepo().FindByName(ctx, comm.SettingTotpEnforced)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询系统配置失败")
+		return comm.CodeDatabaseError
+	}
+	if setting != nil && setting.Value == "true" {
+		return comm.CodePermissionDenied
+	}
+
+	// 校验密码及二次验证码
+	if err := comm.ComparePassword(admin.Password, req.Password); err != nil {
+		return comm.CodeAdminPasswordError
+	}
+	ok, err := verifySecondFactor(ctx, admin, req.Code)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("校验二次验证码失败")
+		return comm.CodeDatabaseError
+	}
+	if !ok {
+		return comm.CodeTotpInvalid
+	}
+
+	// 事务 清除密钥 -> 删除恢复码
+	err = repo.Transaction(func(tx *query.Query) error {
+		if _, err := repo.NewAdminRepo(tx).UpdateTotpSecret(ctx, admin.ID, ""); err != nil {
+			return err
+		}
+		_, err := repo.NewAdminRecoveryCodeRepo(tx).DeleteByAdminID(ctx, admin.ID)
+		return err
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("停用二次验证失败")
+		return comm.CodeDatabaseError
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (t *TotpDisableApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&t.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfTotpDisable API执行入口
+func hfTotpDisable(ctx *gin.Context) {
+	api := &TotpDisableApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/query"
+	"app/dao/repo"
+)
+
+// TotpEnableHandler API router注册点
+func TotpEnableHandler() gin.HandlerFunc {
+	api := TotpEnableApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfTotpEnable).Pointer()).Name()] = api
+	return hfTotpEnable
+}
+
+type TotpEnableApi struct {
+	Info     struct{}              `name:"启用二次验证" desc:"校验验证器应用生成的验证码后启用二次验证 返回恢复码及已通过二次验证的新Token"`
+	Request  TotpEnableApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response TotpEnableApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type TotpEnableApiRequest struct {
+	Body struct {
+		Code string `json:"code" binding:"required,len=6,numeric" desc:"验证器应用生成的验证码"`
+	}
+}
+
+type TotpEnableApiResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" desc:"恢复码 验证器不可用时代替验证码使用 每个仅可使用一次 仅展示一次"`
+	Token         string   `json:"token" desc:"访问Token"`
+	RefreshToken  string   `json:"refresh_token" desc:"刷新Token 访问Token过期后用于续期 仅可使用一次"`
+}
+
+// Run Api业务逻辑执行点
+func (t *TotpEnableApi) Run(ctx *gin.Context) kit.Code {
+	req := t.Request.Body
+
+	// 获取登录管理员信息
+	identity, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询管理员
+	admin, err := repo.NewAdminRepo().FindByID(ctx, identity.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员失败")
+		return comm.CodeDatabaseError
+	}
+	if admin == nil {
+		return comm.CodeAdminNotExist
+	}
+	if admin.TotpSecret != "" {
+		return comm.CodeTotpAlreadyEnabled
+	}
+
+	// 校验待确认的密钥
+	secret, err := cache.NewTotpCache().GetPending(ctx, admin.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询待确认的 TOTP 密钥失败")
+		return comm.CodeRedisError
+	}
+	if secret == "" {
+		return comm.CodeDataNotFound
+	}
+	step, ok := comm.VerifyTotp(secret, req.Code, time.Now())
+	if !ok {
+		return comm.CodeTotpInvalid
+	}
+	if _, err := cache.NewTotpCache().Use(ctx, admin.ID, step); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("标记 TOTP 验证码已使用失败")
+	}
+
+	// 加密密钥
+	encrypted, err := comm.AnswerKeyring.Encrypt(secret)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("TOTP 密钥加密失败")
+		return comm.CodeUnknownError
+	}
+
+	// 事务 保存密钥 -> 生成恢复码
+	recoveryCodes := comm.GenerateRecoveryCodes()
+	err = repo.Transaction(func(tx *query.Query) error {
+		if _, err := repo.NewAdminRepo(tx).UpdateTotpSecret(ctx, admin.ID, encrypted); err != nil {
+			return err
+		}
+		if _, err := repo.NewAdminRecoveryCodeRepo(tx).DeleteByAdminID(ctx, admin.ID); err != nil {
+			return err
+		}
+		return repo.NewAdminRecoveryCodeRepo(tx).BatchCreate(ctx, admin.ID, lo.Map(recoveryCodes, func(code string, _ int) string {
+			return comm.HashRecoveryCode(code)
+		}))
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("启用二次验证失败")
+		return comm.CodeDatabaseError
+	}
+	t.Response.RecoveryCodes = recoveryCodes
+
+	if err := cache.NewTotpCache().DelPending(ctx, admin.ID); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("删除待确认的 TOTP 密钥失败")
+	}
+
+	// 签发已通过二次验证的 Token
+	token, refreshToken, code := issueToken(ctx, comm.AdminIdentity{
+		ID:        admin.ID,
+		Username:  admin.Username,
+		Type:      comm.AdminType(admin.Type),
+		TwoFactor: true,
+	})
+	if code != comm.CodeOK {
+		return code
+	}
+	t.Response.Token = token
+	t.Response.RefreshToken = refreshToken
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (t *TotpEnableApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&t.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfTotpEnable API执行入口
+func hfTotpEnable(ctx *gin.Context) {
+	api := &TotpEnableApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/query"
+	"app/dao/repo"
+)
+
+// TotpRecoveryHandler API router注册点
+func TotpRecoveryHandler() gin.HandlerFunc {
+	api := TotpRecoveryApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfTotpRecovery).Pointer()).Name()] = api
+	return hfTotpRecovery
+}
+
+type TotpRecoveryApi struct {
+	Info     struct{}                `name:"重新生成恢复码" desc:"校验二次验证码后重新生成恢复码 原恢复码全部失效"`
+	Request  TotpRecoveryApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response TotpRecoveryApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type TotpRecoveryApiRequest struct {
+	Body struct {
+		Code string `json:"code" binding:"required,max=32" desc:"二次验证码或恢复码"`
+	}
+}
+
+type TotpRecoveryApiResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" desc:"恢复码 验证器不可用时代替验证码使用 每个仅可使用一次 仅展示一次"`
+}
+
+// Run Api业务逻辑执行点
+func (t *TotpRecoveryApi) Run(ctx *gin.Context) kit.Code {
+	req := t.Request.Body
+
+	// 获取登录管理员信息
+	identity, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询管理员
+	admin, err := repo.NewAdminRepo().FindByID(ctx, identity.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员失败")
+		return comm.CodeDatabaseError
+	}
+	if admin == nil {
+		return comm.CodeAdminNotExist
+	}
+	if admin.TotpSecret == "" {
+		return comm.CodeTotpNotEnabled
+	}
+
+	// 校验二次验证码
+	ok, err := verifySecondFactor(ctx, admin, req.Code)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("校验二次验证码失败")
+		return comm.CodeDatabaseError
+	}
+	if !ok {
+		return comm.CodeTotpInvalid
+	}
+
+	// 事务 删除原恢复码 -> 生成恢复码
+	recoveryCodes := comm.GenerateRecoveryCodes()
+	err = repo.Transaction(func(tx *query.Query) error {
+		if _, err := repo.NewAdminRecoveryCodeRepo(tx).DeleteByAdminID(ctx, admin.ID); err != nil {
+			return err
+		}
+		return repo.NewAdminRecoveryCodeRepo(tx).BatchCreate(ctx, admin.ID, lo.Map(recoveryCodes, func(code string, _ int) string {
+			return comm.HashRecoveryCode(code)
+		}))
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("重新生成恢复码失败")
+		return comm.CodeDatabaseError
+	}
+	t.Response.RecoveryCodes = recoveryCodes
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (t *TotpRecoveryApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&t.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfTotpRecovery API执行入口
+func hfTotpRecovery(ctx *gin.Context) {
+	api := &TotpRecoveryApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
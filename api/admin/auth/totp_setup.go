@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/config"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/repo"
+)
+
+// TotpSetupHandler API router注册点
+func TotpSetupHandler() gin.HandlerFunc {
+	api := TotpSetupApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfTotpSetup).Pointer()).Name()] = api
+	return hfTotpSetup
+}
+
+type TotpSetupApi struct {
+	Info     struct{}             `name:"获取二次验证密钥" desc:"生成待确认的TOTP密钥及验证器应用扫码使用的otpauth URI 10分钟内有效"`
+	Request  TotpSetupApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response TotpSetupApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type TotpSetupApiRequest struct {
+}
+
+type TotpSetupApiResponse struct {
+	Secret string `json:"secret" desc:"TOTP密钥 Base32编码 供手动输入"`
+	URI    string `json:"uri" desc:"otpauth URI 供生成二维码"`
+}
+
+// Run Api业务逻辑执行点
+func (t *TotpSetupApi) Run(ctx *gin.Context) kit.Code {
+	// 获取登录管理员信息
+	identity, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询管理员
+	admin, err := repo.NewAdminRepo().FindByID(ctx, identity.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员失败")
+		return comm.CodeDatabaseError
+	}
+	if admin == nil {
+		return comm.CodeAdminNotExist
+	}
+	if admin.TotpSecret != "" {
+		return comm.CodeTotpAlreadyEnabled
+	}
+
+	// 生成待确认的密钥
+	secret := comm.GenerateTotpSecret()
+	if err := cache.NewTotpCache().SetPending(ctx, admin.ID, secret); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("保存待确认的 TOTP 密钥失败")
+		return comm.CodeRedisError
+	}
+	t.Response.Secret = secret
+	t.Response.URI = comm.TotpURI(config.AppName(), admin.Username, secret)
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (t *TotpSetupApi) Init(ctx *gin.Context) (err error) {
+	return err
+}
+
+// hfTotpSetup API执行入口
+func hfTotpSetup(ctx *gin.Context) {
+	api := &TotpSetupApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package bank
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/model"
+	"app/dao/query"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// InsertHandler API router注册点
+func InsertHandler() gin.HandlerFunc {
+	api := InsertApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfInsert).Pointer()).Name()] = api
+	return hfInsert
+}
+
+type InsertApi struct {
+	Info     struct{}          `name:"插入题库题目" desc:"将题库题目以全新的题目ID插入问卷 避免与问卷现有题目ID冲突"`
+	Request  InsertApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response InsertApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type InsertApiRequest struct {
+	Body struct {
+		SurveyID int64   `json:"survey_id" binding:"required,gte=1" desc:"问卷ID"`
+		IDs      []int64 `json:"ids" binding:"required,min=1,max=50,unique,dive,gte=1" desc:"题库题目ID列表 按列表顺序插入"`
+		AfterID  string  `json:"after_id" desc:"插入到该题目之后 为空时追加到末尾"`
+	}
+}
+
+type InsertApiResponse struct {
+	QuestionIDs []string `json:"question_ids" desc:"插入题目的新ID列表"`
+}
+
+// Run Api业务逻辑执行点
+func (i *InsertApi) Run(ctx *gin.Context) kit.Code {
+	req := i.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询问卷
+	survey, err := repo.NewSurveyRepo().FindByID(ctx, req.SurveyID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
+		return comm.CodeDatabaseError
+	}
+	if survey == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 校验权限
+	if survey.AdminID != admin.ID && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询可见的题库题目
+	entries, err := repo.NewQuestionBankRepo().FindVisibleListByIDs(ctx, admin.ID, req.IDs)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询题库题目失败")
+		return comm.CodeDatabaseError
+	}
+	if len(entries) != len(req.IDs) {
+		return comm.CodeDataNotFound
+	}
+	entryMap := lo.KeyBy(entries, func(item *model.QuestionBank) int64 {
+		return item.ID
+	})
+
+	// 题目结构反序列化
+	items := make([]schema.QuestionItem, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		var item schema.QuestionItem
+		if err := sonic.UnmarshalString(entryMap[id].Data, &item); err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Errorf("题目结构反序列化失败 ID:%d", id)
+			return comm.CodeDataParseError
+		}
+		items = append(items, item)
+	}
+
+	// 问卷结构反序列化 旧问卷结构用于审计日志变更对比
+	var oldSchema, surveySchema schema.SurveySchema
+	if err := sonic.UnmarshalString(survey.Schema, &oldSchema); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("问卷结构反序列化失败")
+		return comm.CodeDataParseError
+	}
+	if err := sonic.UnmarshalString(survey.Schema, &surveySchema); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("问卷结构反序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 以全新ID插入题目
+	questionIDs, err := surveySchema.InsertItems(items, req.AfterID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("插入题目失败")
+		return comm.CodeParameterInvalid
+	}
+
+	// 填充级联数据集
+	if err := repo.NewCascadeDatasetRepo().FillSchema(ctx, &surveySchema); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询级联数据集失败")
+		return comm.CodeDatabaseError
+	}
+
+	// 问卷结构校验
+	surveySchema.AdaptTo(comm.SurveyType(survey.Type))
+	if err := surveySchema.NormalizeAndVerify(); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("问卷结构校验失败")
+		return comm.CodeParameterInvalid
+	}
+
+	// 问卷结构序列化
+	schemaStr, err := sonic.MarshalString(surveySchema)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("问卷结构序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 新增题目的统计数据
+	newIDs := lo.Keyify(questionIDs)
+	statsList := lo.FlatMap(surveySchema.QuestionConf.Items, func(item schema.QuestionItem, _ int) []*model.Stats {
+		if _, ok := newIDs[item.ID]; !ok {
+			return nil
+		}
+		return lo.Map(item.StatsOptionIDs(), func(optionID string, _ int) *model.Stats {
+			return &model.Stats{
+				SurveyID:   survey.ID,
+				QuestionID: item.ID,
+				OptionID:   optionID,
+			}
+		})
+	})
+
+	// 事务 更新问卷 -> 创建新增统计数据 -> 记录审计日志
+	err = repo.Transaction(func(tx *query.Query) error {
+		// 更新问卷
+		if _, err := repo.NewSurveyRepo(tx).UpdateSchema(ctx, survey.ID, surveySchema.BannerConf.TitleConf.MainTitle, schemaStr); err != nil {
+			return err
+		}
+
+		// 创建新增统计数据
+		if len(statsList) > 0 {
+			if err := repo.NewStatsRepo(tx).BatchCreate(ctx, statsList); err != nil {
+				return err
+			}
+		}
+
+		// 记录审计日志
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionSurveyUpdate,
+			TargetType: comm.AuditTargetSurvey,
+			TargetID:   survey.ID,
+			Detail: map[string]any{
+				"title":    surveySchema.BannerConf.TitleConf.MainTitle,
+				"diff":     schema.Diff(&oldSchema, &surveySchema),
+				"bank_ids": req.IDs,
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("插入题库题目失败")
+		return comm.CodeDatabaseError
+	}
+
+	// 删除问卷缓存
+	if err := cache.NewSurveyCache().Del(ctx, survey.Path); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("删除问卷缓存失败")
+	}
+
+	// 标记读主库 避免随后的列表查询读到从库未同步的数据
+	if err := cache.NewPrimaryPinCache().Pin(ctx, admin.ID); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("设置读主库标记失败")
+	}
+
+	i.Response.QuestionIDs = questionIDs
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (i *InsertApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&i.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfInsert API执行入口
+func hfInsert(ctx *gin.Context) {
+	api := &InsertApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
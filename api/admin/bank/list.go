@@ -0,0 +1,132 @@
+package bank
+
+import (
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// ListHandler API router注册点
+func ListHandler() gin.HandlerFunc {
+	api := ListApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfList).Pointer()).Name()] = api
+	return hfList
+}
+
+type ListApi struct {
+	Info     struct{}        `name:"获取题库题目列表" desc:"获取共享题库及本人私有题库中的题目 支持按题型、标签及标题关键词筛选"`
+	Request  ListApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response ListApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type ListApiRequest struct {
+	Query struct {
+		Page     int               `form:"page" binding:"required,gte=1" desc:"页码"`
+		PageSize int               `form:"page_size" binding:"required,gte=1,lte=100" desc:"每页数量"`
+		Scope    comm.BankScope    `form:"scope" binding:"omitempty,oneof=1 2" desc:"范围 1-私有 2-共享 为空时查询全部"`
+		Type     comm.QuestionType `form:"type" binding:"omitempty,max=16" desc:"题型"`
+		Tag      string            `form:"tag" binding:"omitempty,max=16" desc:"标签"`
+		Keyword  string            `form:"keyword" binding:"omitempty,max=64" desc:"标题关键词"`
+	}
+}
+
+type ListApiResponse struct {
+	Page     int        `json:"page" desc:"页码"`
+	PageSize int        `json:"page_size" desc:"每页数量"`
+	List     []BankItem `json:"list" desc:"题目列表"`
+	Total    int64      `json:"total" desc:"总数量"`
+}
+
+type BankItem struct {
+	ID        int64               `json:"id" desc:"题库题目ID"`
+	AdminID   int64               `json:"admin_id" desc:"创建管理员ID"`
+	Scope     comm.BankScope      `json:"scope" desc:"范围 1-私有 2-共享"`
+	Tags      []string            `json:"tags" desc:"标签列表"`
+	Question  schema.QuestionItem `json:"question" desc:"题目结构"`
+	CreatedAt string              `json:"created_at" desc:"创建时间"`
+	UpdatedAt string              `json:"updated_at" desc:"更新时间"`
+}
+
+// Run Api业务逻辑执行点
+func (l *ListApi) Run(ctx *gin.Context) kit.Code {
+	req := l.Request.Query
+	l.Response.Page = req.Page
+	l.Response.PageSize = req.PageSize
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询题目列表
+	list, total, err := repo.NewQuestionBankRepo().FindPage(ctx, req.Page, req.PageSize, admin.ID, req.Scope, req.Type, req.Tag, req.Keyword)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询题库题目列表失败")
+		return comm.CodeDatabaseError
+	}
+	l.Response.Total = total
+
+	// 构建响应数据
+	l.Response.List = make([]BankItem, 0, len(list))
+	for _, entry := range list {
+		item := BankItem{
+			ID:        entry.ID,
+			AdminID:   entry.AdminID,
+			Scope:     comm.BankScope(entry.Scope),
+			CreatedAt: entry.CreatedAt.Format(time.DateTime),
+			UpdatedAt: entry.UpdatedAt.Format(time.DateTime),
+		}
+		if err := sonic.UnmarshalString(entry.Tags, &item.Tags); err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Errorf("标签列表反序列化失败 ID:%d", entry.ID)
+			continue
+		}
+		if err := sonic.UnmarshalString(entry.Data, &item.Question); err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Errorf("题目结构反序列化失败 ID:%d", entry.ID)
+			continue
+		}
+		l.Response.List = append(l.Response.List, item)
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (l *ListApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&l.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfList API执行入口
+func hfList(ctx *gin.Context) {
+	api := &ListApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
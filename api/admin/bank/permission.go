@@ -0,0 +1,14 @@
+package bank
+
+import (
+	"app/comm"
+	"app/dao/model"
+)
+
+// canManage 共享题目仅超级管理员可管理 私有题目仅创建者可管理
+func canManage(admin comm.AdminIdentity, entry *model.QuestionBank) bool {
+	if comm.BankScope(entry.Scope) == comm.BankScopeShared {
+		return admin.Type == comm.AdminTypeSuper
+	}
+	return entry.AdminID == admin.ID
+}
@@ -0,0 +1,155 @@
+package bank
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// SaveHandler API router注册点
+func SaveHandler() gin.HandlerFunc {
+	api := SaveApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfSave).Pointer()).Name()] = api
+	return hfSave
+}
+
+type SaveApi struct {
+	Info     struct{}        `name:"保存题目到题库" desc:"将问卷中的题目保存到题库 包含答案引用的题目不可保存 共享题库仅超级管理员可保存"`
+	Request  SaveApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response SaveApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type SaveApiRequest struct {
+	Body struct {
+		SurveyID   int64          `json:"survey_id" binding:"required,gte=1" desc:"问卷ID"`
+		QuestionID string         `json:"question_id" binding:"required" desc:"题目ID"`
+		Scope      comm.BankScope `json:"scope" binding:"required,oneof=1 2" desc:"范围 1-私有 2-共享"`
+		Tags       []string       `json:"tags" binding:"max=10,unique,dive,required,max=16" desc:"标签列表"`
+	}
+}
+
+type SaveApiResponse struct {
+	ID int64 `json:"id" desc:"题库题目ID"`
+}
+
+// Run Api业务逻辑执行点
+func (s *SaveApi) Run(ctx *gin.Context) kit.Code {
+	req := s.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 共享题库仅超级管理员可保存
+	if req.Scope == comm.BankScopeShared && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询问卷
+	survey, err := repo.NewSurveyRepo().FindByID(ctx, req.SurveyID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
+		return comm.CodeDatabaseError
+	}
+	if survey == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 校验权限
+	if survey.AdminID != admin.ID && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 问卷结构反序列化
+	var surveySchema schema.SurveySchema
+	if err := sonic.UnmarshalString(survey.Schema, &surveySchema); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("问卷结构反序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 查找题目
+	item, ok := lo.Find(surveySchema.QuestionConf.Items, func(item schema.QuestionItem) bool {
+		return item.ID == req.QuestionID
+	})
+	if !ok {
+		return comm.CodeDataNotFound
+	}
+
+	// 答案引用依赖原问卷的题目ID 不可复用
+	if item.HasPipe() {
+		nlog.Pick().WithContext(ctx).Warnf("包含答案引用的题目不可保存到题库 ID:%s", item.ID)
+		return comm.CodeParameterInvalid
+	}
+
+	// 题目及标签序列化
+	data, err := sonic.MarshalString(item)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("题目结构序列化失败")
+		return comm.CodeDataParseError
+	}
+	tags, err := sonic.MarshalString(lo.Ternary(req.Tags == nil, []string{}, req.Tags))
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("标签列表序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 保存到题库
+	entry := &model.QuestionBank{
+		AdminID: admin.ID,
+		Scope:   int8(req.Scope),
+		Title:   item.Title,
+		Type:    string(item.Type),
+		Tags:    tags,
+		Data:    data,
+	}
+	if err := repo.NewQuestionBankRepo().Create(ctx, entry); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("保存题目到题库失败")
+		return comm.CodeDatabaseError
+	}
+	s.Response.ID = entry.ID
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (s *SaveApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&s.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfSave API执行入口
+func hfSave(ctx *gin.Context) {
+	api := &SaveApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
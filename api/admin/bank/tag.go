@@ -0,0 +1,109 @@
+package bank
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/repo"
+)
+
+// TagHandler API router注册点
+func TagHandler() gin.HandlerFunc {
+	api := TagApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfTag).Pointer()).Name()] = api
+	return hfTag
+}
+
+type TagApi struct {
+	Info     struct{}       `name:"设置题库题目标签" desc:"覆盖设置题库题目的标签列表"`
+	Request  TagApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response TagApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type TagApiRequest struct {
+	Body struct {
+		ID   int64    `json:"id" binding:"required,gte=1" desc:"题库题目ID"`
+		Tags []string `json:"tags" binding:"max=10,unique,dive,required,max=16" desc:"标签列表"`
+	}
+}
+
+type TagApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (t *TagApi) Run(ctx *gin.Context) kit.Code {
+	req := t.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询题目
+	entry, err := repo.NewQuestionBankRepo().FindByID(ctx, req.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询题库题目失败")
+		return comm.CodeDatabaseError
+	}
+	if entry == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 校验权限
+	if !canManage(admin, entry) {
+		return comm.CodePermissionDenied
+	}
+
+	// 标签列表序列化
+	tags, err := sonic.MarshalString(lo.Ternary(req.Tags == nil, []string{}, req.Tags))
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("标签列表序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 更新标签
+	if _, err := repo.NewQuestionBankRepo().UpdateTags(ctx, entry.ID, tags); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("更新题库题目标签失败")
+		return comm.CodeDatabaseError
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (t *TagApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&t.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfTag API执行入口
+func hfTag(ctx *gin.Context) {
+	api := &TagApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
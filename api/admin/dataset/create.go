@@ -0,0 +1,109 @@
+package dataset
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// CreateHandler API router注册点
+func CreateHandler() gin.HandlerFunc {
+	api := CreateApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfCreate).Pointer()).Name()] = api
+	return hfCreate
+}
+
+type CreateApi struct {
+	Info     struct{}          `name:"创建级联数据集" desc:"创建可供级联选择题复用的选项树"`
+	Request  CreateApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response CreateApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type CreateApiRequest struct {
+	Body struct {
+		Name string               `json:"name" binding:"required,max=64" desc:"名称"`
+		Data []schema.CascadeNode `json:"data" binding:"required,min=1,dive" desc:"级联选项树"`
+	}
+}
+
+type CreateApiResponse struct {
+	ID int64 `json:"id" desc:"数据集ID"`
+}
+
+// Run Api业务逻辑执行点
+func (c *CreateApi) Run(ctx *gin.Context) kit.Code {
+	req := c.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 选项树校验
+	if err := schema.VerifyCascadeNodes(req.Data); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("级联选项树校验失败")
+		return comm.CodeParameterInvalid
+	}
+
+	// 选项树序列化
+	data, err := sonic.MarshalString(req.Data)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("级联选项树序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 创建数据集
+	dataset := &model.CascadeDataset{
+		AdminID: admin.ID,
+		Name:    req.Name,
+		Data:    data,
+	}
+	if err := repo.NewCascadeDatasetRepo().Create(ctx, dataset); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("创建级联数据集失败")
+		return comm.CodeDatabaseError
+	}
+	c.Response.ID = dataset.ID
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (c *CreateApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&c.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfCreate API执行入口
+func hfCreate(ctx *gin.Context) {
+	api := &CreateApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package dataset
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/repo"
+)
+
+// DeleteHandler API router注册点
+func DeleteHandler() gin.HandlerFunc {
+	api := DeleteApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfDelete).Pointer()).Name()] = api
+	return hfDelete
+}
+
+type DeleteApi struct {
+	Info     struct{}          `name:"删除级联数据集" desc:"删除级联数据集 不影响已引用该数据集的问卷"`
+	Request  DeleteApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response DeleteApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type DeleteApiRequest struct {
+	Body struct {
+		ID int64 `json:"id" binding:"required,gte=1" desc:"数据集ID"`
+	}
+}
+
+type DeleteApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (d *DeleteApi) Run(ctx *gin.Context) kit.Code {
+	req := d.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询数据集
+	dataset, err := repo.NewCascadeDatasetRepo().FindByID(ctx, req.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询级联数据集失败")
+		return comm.CodeDatabaseError
+	}
+	if dataset == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 校验权限
+	if dataset.AdminID != admin.ID && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 删除数据集
+	if _, err := repo.NewCascadeDatasetRepo().DeleteByID(ctx, dataset.ID); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("删除级联数据集失败")
+		return comm.CodeDatabaseError
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (d *DeleteApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&d.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfDelete API执行入口
+func hfDelete(ctx *gin.Context) {
+	api := &DeleteApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
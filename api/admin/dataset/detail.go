@@ -0,0 +1,95 @@
+package dataset
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// DetailHandler API router注册点
+func DetailHandler() gin.HandlerFunc {
+	api := DetailApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfDetail).Pointer()).Name()] = api
+	return hfDetail
+}
+
+type DetailApi struct {
+	Info     struct{}          `name:"获取级联数据集详情" desc:"获取级联数据集详情"`
+	Request  DetailApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response DetailApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type DetailApiRequest struct {
+	Query struct {
+		ID int64 `form:"id" binding:"required,gte=1" desc:"数据集ID"`
+	}
+}
+
+type DetailApiResponse struct {
+	ID   int64                `json:"id" desc:"数据集ID"`
+	Name string               `json:"name" desc:"名称"`
+	Data []schema.CascadeNode `json:"data" desc:"级联选项树"`
+}
+
+// Run Api业务逻辑执行点
+func (d *DetailApi) Run(ctx *gin.Context) kit.Code {
+	req := d.Request.Query
+
+	// 查询数据集
+	dataset, err := repo.NewCascadeDatasetRepo().FindByID(ctx, req.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询级联数据集失败")
+		return comm.CodeDatabaseError
+	}
+	if dataset == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 选项树反序列化
+	if err := sonic.UnmarshalString(dataset.Data, &d.Response.Data); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("级联选项树反序列化失败")
+		return comm.CodeDataParseError
+	}
+	d.Response.ID = dataset.ID
+	d.Response.Name = dataset.Name
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (d *DetailApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&d.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfDetail API执行入口
+func hfDetail(ctx *gin.Context) {
+	api := &DetailApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
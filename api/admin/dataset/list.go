@@ -0,0 +1,110 @@
+package dataset
+
+import (
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/repo"
+)
+
+// ListHandler API router注册点
+func ListHandler() gin.HandlerFunc {
+	api := ListApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfList).Pointer()).Name()] = api
+	return hfList
+}
+
+type ListApi struct {
+	Info     struct{}        `name:"获取级联数据集列表" desc:"获取级联数据集列表 数据集对全部管理员可见"`
+	Request  ListApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response ListApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type ListApiRequest struct {
+	Query struct {
+		Page     int    `form:"page" binding:"required,gte=1" desc:"页码"`
+		PageSize int    `form:"page_size" binding:"required,gte=1,lte=100" desc:"每页数量"`
+		Keyword  string `form:"keyword" binding:"omitempty,max=64" desc:"搜索关键词"`
+	}
+}
+
+type ListApiResponse struct {
+	Page     int           `json:"page" desc:"页码"`
+	PageSize int           `json:"page_size" desc:"每页数量"`
+	List     []DatasetItem `json:"list" desc:"数据集列表"`
+	Total    int64         `json:"total" desc:"总数量"`
+}
+
+type DatasetItem struct {
+	ID        int64  `json:"id" desc:"数据集ID"`
+	AdminID   int64  `json:"admin_id" desc:"创建管理员ID"`
+	Name      string `json:"name" desc:"名称"`
+	CreatedAt string `json:"created_at" desc:"创建时间"`
+	UpdatedAt string `json:"updated_at" desc:"更新时间"`
+}
+
+// Run Api业务逻辑执行点
+func (l *ListApi) Run(ctx *gin.Context) kit.Code {
+	req := l.Request.Query
+	l.Response.Page = req.Page
+	l.Response.PageSize = req.PageSize
+
+	// 查询数据集列表
+	list, total, err := repo.NewCascadeDatasetRepo().FindPage(ctx, req.Page, req.PageSize, req.Keyword)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询级联数据集列表失败")
+		return comm.CodeDatabaseError
+	}
+	l.Response.Total = total
+
+	// 构建响应数据
+	l.Response.List = lo.Map(list, func(item *model.CascadeDataset, _ int) DatasetItem {
+		return DatasetItem{
+			ID:        item.ID,
+			AdminID:   item.AdminID,
+			Name:      item.Name,
+			CreatedAt: item.CreatedAt.Format(time.DateTime),
+			UpdatedAt: item.UpdatedAt.Format(time.DateTime),
+		}
+	})
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (l *ListApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&l.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfList API执行入口
+func hfList(ctx *gin.Context) {
+	api := &ListApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package dataset
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// UpdateHandler API router注册点
+func UpdateHandler() gin.HandlerFunc {
+	api := UpdateApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfUpdate).Pointer()).Name()] = api
+	return hfUpdate
+}
+
+type UpdateApi struct {
+	Info     struct{}          `name:"更新级联数据集" desc:"更新级联数据集 已引用该数据集的问卷需重新保存后生效"`
+	Request  UpdateApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response UpdateApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type UpdateApiRequest struct {
+	Body struct {
+		ID   int64                `json:"id" binding:"required,gte=1" desc:"数据集ID"`
+		Name string               `json:"name" binding:"required,max=64" desc:"名称"`
+		Data []schema.CascadeNode `json:"data" binding:"required,min=1,dive" desc:"级联选项树"`
+	}
+}
+
+type UpdateApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (u *UpdateApi) Run(ctx *gin.Context) kit.Code {
+	req := u.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询数据集
+	dataset, err := repo.NewCascadeDatasetRepo().FindByID(ctx, req.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询级联数据集失败")
+		return comm.CodeDatabaseError
+	}
+	if dataset == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 校验权限
+	if dataset.AdminID != admin.ID && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 选项树校验
+	if err := schema.VerifyCascadeNodes(req.Data); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("级联选项树校验失败")
+		return comm.CodeParameterInvalid
+	}
+
+	// 选项树序列化
+	data, err := sonic.MarshalString(req.Data)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("级联选项树序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 更新数据集
+	if _, err := repo.NewCascadeDatasetRepo().Update(ctx, dataset.ID, req.Name, data); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("更新级联数据集失败")
+		return comm.CodeDatabaseError
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (u *UpdateApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&u.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfUpdate API执行入口
+func hfUpdate(ctx *gin.Context) {
+	api := &UpdateApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package invite
+
+import (
+	"crypto/rand"
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/query"
+	"app/dao/repo"
+)
+
+// CreateHandler API router注册点
+func CreateHandler() gin.HandlerFunc {
+	api := CreateApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfCreate).Pointer()).Name()] = api
+	return hfCreate
+}
+
+type CreateApi struct {
+	Info     struct{}          `name:"生成邀请码" desc:"生成用于创建普通管理员的邀请码 仅超级管理员可用"`
+	Request  CreateApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response CreateApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type CreateApiRequest struct {
+	Body struct {
+		MaxUses  int32  `json:"max_uses" binding:"required,gte=1,lte=100" desc:"可使用次数"`
+		ExpireAt string `json:"expire_at" binding:"required,datetime=2006-01-02 15:04:05" desc:"过期时间"`
+		Username string `json:"username" binding:"omitempty,max=16" desc:"预分配用户名 为空表示不限 指定时仅能创建该用户名的管理员"`
+	}
+}
+
+type CreateApiResponse struct {
+	ID   int64  `json:"id" desc:"邀请码ID"`
+	Code string `json:"code" desc:"邀请码"`
+}
+
+// Run Api业务逻辑执行点
+func (c *CreateApi) Run(ctx *gin.Context) kit.Code {
+	req := c.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 校验过期时间
+	expireAt, _ := time.ParseInLocation(time.DateTime, req.ExpireAt, time.Local)
+	if !expireAt.After(time.Now()) {
+		return comm.CodeParameterInvalid
+	}
+
+	// 校验预分配用户名
+	if req.Username != "" {
+		record, err := repo.NewAdminRepo().FindByUsername(ctx, req.Username)
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员失败")
+			return comm.CodeDatabaseError
+		}
+		if record != nil {
+			return comm.CodeAdminAlreadyExist
+		}
+		// 预分配用户名的邀请码仅可使用一次
+		req.MaxUses = 1
+	}
+
+	// 创建邀请码
+	invite := &model.AdminInvite{
+		Code:      rand.Text(),
+		CreatorID: admin.ID,
+		Username:  req.Username,
+		MaxUses:   req.MaxUses,
+		ExpireAt:  expireAt,
+	}
+	// 事务 创建邀请码 -> 记录审计日志 审计日志不记录邀请码明文
+	err = repo.Transaction(func(tx *query.Query) error {
+		if err := repo.NewAdminInviteRepo(tx).Create(ctx, invite); err != nil {
+			return err
+		}
+
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionInviteCreate,
+			TargetType: comm.AuditTargetInvite,
+			TargetID:   invite.ID,
+			Detail: map[string]any{
+				"username":  invite.Username,
+				"max_uses":  invite.MaxUses,
+				"expire_at": req.ExpireAt,
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("创建邀请码失败")
+		return comm.CodeDatabaseError
+	}
+	c.Response.ID = invite.ID
+	c.Response.Code = invite.Code
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (c *CreateApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&c.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfCreate API执行入口
+func hfCreate(ctx *gin.Context) {
+	api := &CreateApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package invite
+
+import (
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/query"
+	"app/dao/repo"
+)
+
+// ExpireHandler API router注册点
+func ExpireHandler() gin.HandlerFunc {
+	api := ExpireApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfExpire).Pointer()).Name()] = api
+	return hfExpire
+}
+
+type ExpireApi struct {
+	Info     struct{}          `name:"作废邀请码" desc:"使邀请码立即过期 使用记录保留 仅超级管理员可用"`
+	Request  ExpireApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response ExpireApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type ExpireApiRequest struct {
+	Body struct {
+		ID int64 `json:"id" binding:"required,gte=1" desc:"邀请码ID"`
+	}
+}
+
+type ExpireApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (e *ExpireApi) Run(ctx *gin.Context) kit.Code {
+	req := e.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询邀请码
+	invite, err := repo.NewAdminInviteRepo().FindByID(ctx, req.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询邀请码失败")
+		return comm.CodeDatabaseError
+	}
+	if invite == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 事务 作废邀请码 -> 记录审计日志 已过期时无需处理
+	err = repo.Transaction(func(tx *query.Query) error {
+		count, err := repo.NewAdminInviteRepo(tx).Expire(ctx, invite.ID, time.Now())
+		if err != nil || count == 0 {
+			return err
+		}
+
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionInviteExpire,
+			TargetType: comm.AuditTargetInvite,
+			TargetID:   invite.ID,
+			Detail: map[string]any{
+				"username": invite.Username,
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("作废邀请码失败")
+		return comm.CodeDatabaseError
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (e *ExpireApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&e.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfExpire API执行入口
+func hfExpire(ctx *gin.Context) {
+	api := &ExpireApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
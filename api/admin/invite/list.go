@@ -0,0 +1,173 @@
+package invite
+
+import (
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/repo"
+)
+
+// ListHandler API router注册点
+func ListHandler() gin.HandlerFunc {
+	api := ListApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfList).Pointer()).Name()] = api
+	return hfList
+}
+
+type ListApi struct {
+	Info     struct{}        `name:"获取邀请码列表" desc:"获取全部已生成的邀请码及其使用记录 仅超级管理员可用"`
+	Request  ListApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response ListApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type ListApiRequest struct {
+	Query struct {
+		Page     int `form:"page" binding:"required,gte=1" desc:"页码"`
+		PageSize int `form:"page_size" binding:"required,gte=1,lte=100" desc:"每页数量"`
+	}
+}
+
+type ListApiResponse struct {
+	Page     int          `json:"page" desc:"页码"`
+	PageSize int          `json:"page_size" desc:"每页数量"`
+	List     []InviteItem `json:"list" desc:"邀请码列表"`
+	Total    int64        `json:"total" desc:"总数量"`
+}
+
+type InviteItem struct {
+	ID          int64     `json:"id" desc:"邀请码ID"`
+	Code        string    `json:"code" desc:"邀请码"`
+	CreatorID   int64     `json:"creator_id" desc:"创建管理员ID"`
+	CreatorName string    `json:"creator_name" desc:"创建管理员用户名 管理员已删除时为空"`
+	Username    string    `json:"username" desc:"预分配用户名 为空表示不限"`
+	MaxUses     int32     `json:"max_uses" desc:"可使用次数"`
+	UsedCount   int32     `json:"used_count" desc:"已使用次数"`
+	Available   bool      `json:"available" desc:"是否可用 未过期且次数未用尽"`
+	ExpireAt    string    `json:"expire_at" desc:"过期时间"`
+	CreatedAt   string    `json:"created_at" desc:"创建时间"`
+	Uses        []UseItem `json:"uses" desc:"使用记录"`
+}
+
+type UseItem struct {
+	AdminID   int64  `json:"admin_id" desc:"创建的管理员ID"`
+	Username  string `json:"username" desc:"创建的管理员用户名"`
+	CreatedAt string `json:"created_at" desc:"使用时间"`
+}
+
+// Run Api业务逻辑执行点
+func (l *ListApi) Run(ctx *gin.Context) kit.Code {
+	req := l.Request.Query
+	l.Response.Page = req.Page
+	l.Response.PageSize = req.PageSize
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询邀请码列表
+	list, total, err := repo.NewAdminInviteRepo().FindPage(ctx, req.Page, req.PageSize)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询邀请码列表失败")
+		return comm.CodeDatabaseError
+	}
+	l.Response.Total = total
+
+	// 查询创建管理员及使用记录
+	creatorMap := make(map[int64]string)
+	useMap := make(map[int64][]UseItem)
+	if len(list) > 0 {
+		creatorIDs := lo.Uniq(lo.Map(list, func(item *model.AdminInvite, _ int) int64 {
+			return item.CreatorID
+		}))
+		creators, err := repo.NewAdminRepo().FindListByIDs(ctx, creatorIDs)
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员列表失败")
+			return comm.CodeDatabaseError
+		}
+		creatorMap = lo.SliceToMap(creators, func(item *model.Admin) (int64, string) {
+			return item.ID, item.Username
+		})
+
+		inviteIDs := lo.Map(list, func(item *model.AdminInvite, _ int) int64 {
+			return item.ID
+		})
+		uses, err := repo.NewAdminInviteUseRepo().FindListByInviteIDs(ctx, inviteIDs)
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询邀请码使用记录失败")
+			return comm.CodeDatabaseError
+		}
+		for _, use := range uses {
+			useMap[use.InviteID] = append(useMap[use.InviteID], UseItem{
+				AdminID:   use.AdminID,
+				Username:  use.Username,
+				CreatedAt: use.CreatedAt.Format(time.DateTime),
+			})
+		}
+	}
+
+	// 构建响应数据
+	now := time.Now()
+	l.Response.List = lo.Map(list, func(item *model.AdminInvite, _ int) InviteItem {
+		return InviteItem{
+			ID:          item.ID,
+			Code:        item.Code,
+			CreatorID:   item.CreatorID,
+			CreatorName: creatorMap[item.CreatorID],
+			Username:    item.Username,
+			MaxUses:     item.MaxUses,
+			UsedCount:   item.UsedCount,
+			Available:   item.UsedCount < item.MaxUses && item.ExpireAt.After(now),
+			ExpireAt:    item.ExpireAt.Format(time.DateTime),
+			CreatedAt:   item.CreatedAt.Format(time.DateTime),
+			Uses:        lo.CoalesceSliceOrEmpty(useMap[item.ID]),
+		}
+	})
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (l *ListApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&l.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfList API执行入口
+func hfList(ctx *gin.Context) {
+	api := &ListApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
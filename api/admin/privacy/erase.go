@@ -0,0 +1,180 @@
+package privacy
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/query"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// EraseHandler API router注册点
+func EraseHandler() gin.HandlerFunc {
+	api := EraseApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfErase).Pointer()).Name()] = api
+	return hfErase
+}
+
+type EraseApi struct {
+	Info     struct{}         `name:"删除用户答卷" desc:"删除指定用户提交的全部答卷并扣减对应统计数据 用于处理个人信息删除请求 仅超级管理员可用"`
+	Request  EraseApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response EraseApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type EraseApiRequest struct {
+	Body struct {
+		Username string `json:"username" binding:"required,max=16" desc:"用户名"`
+	}
+}
+
+type EraseApiResponse struct {
+	Count int `json:"count" desc:"删除的答卷数量"`
+}
+
+// Run Api业务逻辑执行点
+func (e *EraseApi) Run(ctx *gin.Context) kit.Code {
+	req := e.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询答卷及问卷
+	list, err := repo.NewResultRepo().FindListByUsernameWithArchive(ctx, req.Username)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询用户答卷列表失败")
+		return comm.CodeDatabaseError
+	}
+	if len(list) == 0 {
+		return comm.CodeOK
+	}
+	surveyMap, err := findSurveyMap(ctx, list)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷列表失败")
+		return comm.CodeDatabaseError
+	}
+	schemaMap, err := parseSchemaMap(surveyMap)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("问卷结构反序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 计算需扣减的统计数据 问卷已删除时无需扣减
+	statsUpdates := make(map[int64][][]repo.StatsUpdate)
+	for _, res := range list {
+		surveySchema, ok := schemaMap[res.SurveyID]
+		if !ok {
+			continue
+		}
+		var items []comm.ResultItem
+		if err := sonic.UnmarshalString(res.Data, &items); err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Errorf("答卷数据解析失败 ID:%d", res.ID)
+			return comm.CodeDataParseError
+		}
+		answerMap := lo.SliceToMap(items, func(item comm.ResultItem) (string, string) {
+			return item.QuestionID, item.Answer
+		})
+		updates := lo.Map(surveySchema.SelectedStats(answerMap), func(ref schema.StatsRef, _ int) repo.StatsUpdate {
+			return repo.StatsUpdate{
+				QuestionID: ref.QuestionID,
+				OptionID:   ref.OptionID,
+			}
+		})
+		if len(updates) > 0 {
+			statsUpdates[res.SurveyID] = append(statsUpdates[res.SurveyID], updates)
+		}
+	}
+
+	// 事务 扣减统计数据 -> 删除答卷 -> 记录审计日志
+	err = repo.Transaction(func(tx *query.Query) error {
+		statsRepo := repo.NewStatsRepo(tx)
+		for surveyID, group := range statsUpdates {
+			for _, updates := range group {
+				if _, err := statsRepo.BatchDecr(ctx, surveyID, updates); err != nil {
+					return err
+				}
+			}
+		}
+
+		ids := lo.Map(list, func(res *model.Result, _ int) int64 {
+			return res.ID
+		})
+		if _, err := repo.NewResultRepo(tx).DeleteByIDs(ctx, ids); err != nil {
+			return err
+		}
+		if _, err := repo.NewResultRepo(tx).Archive().DeleteByIDs(ctx, ids); err != nil {
+			return err
+		}
+
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionPrivacyErase,
+			TargetType: comm.AuditTargetUser,
+			Detail: map[string]any{
+				"username": req.Username,
+				"count":    len(ids),
+				"survey_ids": lo.Uniq(lo.Map(list, func(res *model.Result, _ int) int64 {
+					return res.SurveyID
+				})),
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("删除用户答卷失败")
+		return comm.CodeDatabaseError
+	}
+
+	e.Response.Count = len(list)
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (e *EraseApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&e.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfErase API执行入口
+func hfErase(ctx *gin.Context) {
+	api := &EraseApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package privacy
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// ExportHandler API router注册点
+func ExportHandler() gin.HandlerFunc {
+	api := ExportApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfExport).Pointer()).Name()] = api
+	return hfExport
+}
+
+type ExportApi struct {
+	Info     struct{}          `name:"导出用户答卷" desc:"导出指定用户提交的全部答卷为JSON文件 敏感信息为明文 成功时直接返回文件内容 仅超级管理员可用"`
+	Request  ExportApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response ExportApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type ExportApiRequest struct {
+	Query struct {
+		Username string `form:"username" binding:"required,max=16" desc:"用户名"`
+	}
+}
+
+type ExportApiResponse struct {
+	Filename string `json:"-"`
+	Content  []byte `json:"-"`
+}
+
+type ExportResult struct {
+	ResultID    int64          `json:"result_id"`
+	SurveyID    int64          `json:"survey_id"`
+	SurveyTitle string         `json:"survey_title"`
+	CreatedAt   string         `json:"created_at"`
+	Answers     []ExportAnswer `json:"answers"`
+}
+
+type ExportAnswer struct {
+	QuestionID string `json:"question_id"`
+	Title      string `json:"title"`
+	Answer     string `json:"answer"`
+}
+
+// Run Api业务逻辑执行点
+func (e *ExportApi) Run(ctx *gin.Context) kit.Code {
+	req := e.Request.Query
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询答卷及问卷
+	list, err := repo.NewResultRepo().FindListByUsernameWithArchive(ctx, req.Username)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询用户答卷列表失败")
+		return comm.CodeDatabaseError
+	}
+	surveyMap, err := findSurveyMap(ctx, list)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷列表失败")
+		return comm.CodeDatabaseError
+	}
+	schemaMap, err := parseSchemaMap(surveyMap)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("问卷结构反序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 构建导出内容
+	results := make([]ExportResult, 0, len(list))
+	for _, res := range list {
+		var items []comm.ResultItem
+		if err := sonic.UnmarshalString(res.Data, &items); err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Errorf("答卷数据解析失败 ID:%d", res.ID)
+			return comm.CodeDataParseError
+		}
+
+		// 解密敏感答案 答案引用按解密后的答案渲染
+		for i := range items {
+			items[i].Answer, err = comm.AnswerKeyring.Decrypt(items[i].Answer)
+			if err != nil {
+				nlog.Pick().WithContext(ctx).WithError(err).Errorf("敏感答案解密失败 ID:%d", res.ID)
+				return comm.CodeDataParseError
+			}
+		}
+		answerMap := lo.SliceToMap(items, func(item comm.ResultItem) (string, string) {
+			return item.QuestionID, item.Answer
+		})
+
+		// 选项类题目转换为选项文本
+		answers := make([]ExportAnswer, 0, len(items))
+		for _, item := range items {
+			answer := item.Answer
+			exportAnswer := ExportAnswer{
+				QuestionID: item.QuestionID,
+				Answer:     answer,
+			}
+			if surveySchema, ok := schemaMap[res.SurveyID]; ok {
+				if question, ok := lo.Find(surveySchema.QuestionConf.Items, func(q schema.QuestionItem) bool {
+					return q.ID == item.QuestionID
+				}); ok {
+					exportAnswer.Title = question.Title
+					if question.IsOptionType() || question.IsCascadeType() {
+						exportAnswer.Answer = surveySchema.AnswerText(&question, answer, answerMap)
+					}
+				}
+			}
+			answers = append(answers, exportAnswer)
+		}
+
+		exportResult := ExportResult{
+			ResultID:  res.ID,
+			SurveyID:  res.SurveyID,
+			CreatedAt: res.CreatedAt.Format(time.DateTime),
+			Answers:   answers,
+		}
+		if survey, ok := surveyMap[res.SurveyID]; ok {
+			exportResult.SurveyTitle = survey.Title
+		}
+		results = append(results, exportResult)
+	}
+	content, err := sonic.ConfigStd.MarshalIndent(results, "", "  ")
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("导出内容序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 记录审计日志 记录失败时不返回导出内容
+	err = repo.NewAuditLogRepo().Record(ctx, repo.AuditEntry{
+		AdminID:    admin.ID,
+		Action:     comm.AuditActionPrivacyExport,
+		TargetType: comm.AuditTargetUser,
+		Detail: map[string]any{
+			"username": req.Username,
+			"count":    len(results),
+		},
+		IP:        ctx.ClientIP(),
+		RequestID: requestid.Get(ctx),
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("记录审计日志失败")
+		return comm.CodeDatabaseError
+	}
+
+	e.Response.Filename = fmt.Sprintf("%s_答卷_%s.json", req.Username, time.Now().Format("20060102150405"))
+	e.Response.Content = content
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (e *ExportApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&e.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfExport API执行入口
+func hfExport(ctx *gin.Context) {
+	api := &ExportApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			ctx.Header("Content-Disposition", "attachment; filename*=UTF-8''"+url.PathEscape(api.Response.Filename))
+			ctx.Data(http.StatusOK, "application/json; charset=utf-8", api.Response.Content)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
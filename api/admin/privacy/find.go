@@ -0,0 +1,130 @@
+package privacy
+
+import (
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/repo"
+)
+
+// FindHandler API router注册点
+func FindHandler() gin.HandlerFunc {
+	api := FindApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfFind).Pointer()).Name()] = api
+	return hfFind
+}
+
+type FindApi struct {
+	Info     struct{}        `name:"查找用户答卷" desc:"跨问卷查找指定用户提交的全部答卷 用于处理个人信息查询请求 仅超级管理员可用"`
+	Request  FindApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response FindApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type FindApiRequest struct {
+	Query struct {
+		Username string `form:"username" binding:"required,max=16" desc:"用户名"`
+		Page     int    `form:"page" binding:"required,gte=1" desc:"页码"`
+		PageSize int    `form:"page_size" binding:"required,gte=1,lte=100" desc:"每页数量"`
+	}
+}
+
+type FindApiResponse struct {
+	Page     int        `json:"page" desc:"页码"`
+	PageSize int        `json:"page_size" desc:"每页数量"`
+	List     []FindItem `json:"list" desc:"答卷列表"`
+	Total    int64      `json:"total" desc:"总数量"`
+}
+
+type FindItem struct {
+	ResultID    int64  `json:"result_id" desc:"答卷ID"`
+	SurveyID    int64  `json:"survey_id" desc:"问卷ID"`
+	SurveyTitle string `json:"survey_title" desc:"问卷标题 问卷已删除时为空"`
+	CreatedAt   string `json:"created_at" desc:"提交时间"`
+}
+
+// Run Api业务逻辑执行点
+func (f *FindApi) Run(ctx *gin.Context) kit.Code {
+	req := f.Request.Query
+	f.Response.Page = req.Page
+	f.Response.PageSize = req.PageSize
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询答卷列表
+	list, total, err := repo.NewResultRepo().FindPageByUsernameWithArchive(ctx, req.Username, req.Page, req.PageSize)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询用户答卷列表失败")
+		return comm.CodeDatabaseError
+	}
+	f.Response.Total = total
+
+	// 查询问卷标题
+	surveyMap, err := findSurveyMap(ctx, list)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷列表失败")
+		return comm.CodeDatabaseError
+	}
+
+	// 构建响应数据
+	f.Response.List = lo.Map(list, func(res *model.Result, _ int) FindItem {
+		item := FindItem{
+			ResultID:  res.ID,
+			SurveyID:  res.SurveyID,
+			CreatedAt: res.CreatedAt.Format(time.DateTime),
+		}
+		if survey, ok := surveyMap[res.SurveyID]; ok {
+			item.SurveyTitle = survey.Title
+		}
+		return item
+	})
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (f *FindApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&f.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfFind API执行入口
+func hfFind(ctx *gin.Context) {
+	api := &FindApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package privacy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+	"github.com/samber/lo"
+
+	"app/dao/model"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// findSurveyMap 查询答卷所属问卷 map[SurveyID]Survey 已删除的问卷不返回
+func findSurveyMap(ctx context.Context, list []*model.Result) (map[int64]*model.Survey, error) {
+	if len(list) == 0 {
+		return map[int64]*model.Survey{}, nil
+	}
+	surveyIDs := lo.Uniq(lo.Map(list, func(res *model.Result, _ int) int64 {
+		return res.SurveyID
+	}))
+	surveys, err := repo.NewSurveyRepo().FindListByIDs(ctx, surveyIDs)
+	if err != nil {
+		return nil, err
+	}
+	return lo.KeyBy(surveys, func(survey *model.Survey) int64 {
+		return survey.ID
+	}), nil
+}
+
+// parseSchemaMap 问卷结构反序列化 map[SurveyID]SurveySchema
+func parseSchemaMap(surveyMap map[int64]*model.Survey) (map[int64]*schema.SurveySchema, error) {
+	schemaMap := make(map[int64]*schema.SurveySchema, len(surveyMap))
+	for id, survey := range surveyMap {
+		var surveySchema schema.SurveySchema
+		if err := sonic.UnmarshalString(survey.Schema, &surveySchema); err != nil {
+			return nil, fmt.Errorf("问卷结构反序列化失败 ID:%d: %w", id, err)
+		}
+		schemaMap[id] = &surveySchema
+	}
+	return schemaMap, nil
+}
@@ -0,0 +1,204 @@
+package result
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// ExportHandler API router注册点
+func ExportHandler() gin.HandlerFunc {
+	api := ExportApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfExport).Pointer()).Name()] = api
+	return hfExport
+}
+
+type ExportApi struct {
+	Info     struct{}          `name:"导出答卷" desc:"导出全部答卷为CSV文件 成功时直接返回文件内容 敏感信息默认脱敏"`
+	Request  ExportApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response ExportApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type ExportApiRequest struct {
+	Query struct {
+		SurveyID int64  `form:"survey_id" binding:"required,gte=1" desc:"问卷ID"`
+		Locale   string `form:"locale" binding:"omitempty,bcp47_language_tag" desc:"展示语言 按问卷启用的语言匹配 为空或未匹配时使用默认文本"`
+		Reveal   bool   `form:"reveal" desc:"是否导出敏感信息明文 仅超级管理员可用"`
+	}
+}
+
+type ExportApiResponse struct {
+	Filename string `json:"-"`
+	Content  []byte `json:"-"`
+}
+
+// Run Api业务逻辑执行点
+func (e *ExportApi) Run(ctx *gin.Context) kit.Code {
+	req := e.Request.Query
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询问卷
+	survey, err := repo.NewSurveyRepo().FindByID(ctx, req.SurveyID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
+		return comm.CodeDatabaseError
+	}
+	if survey == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 校验权限
+	if survey.AdminID != admin.ID && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+	if req.Reveal && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 问卷结构反序列化
+	var surveySchema schema.SurveySchema
+	if err := sonic.UnmarshalString(survey.Schema, &surveySchema); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("问卷结构反序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 切换展示语言 按语言标识匹配问卷启用的语言 如en-US匹配en
+	locale := surveySchema.MatchLocale(req.Locale, "")
+	surveySchema.Localize(locale)
+	isQuiz := comm.SurveyType(survey.Type) == comm.SurveyTypeQuiz
+
+	// 构建表头
+	buf := &bytes.Buffer{}
+	buf.WriteString("\xEF\xBB\xBF") // UTF-8 BOM 兼容 Excel
+	w := csv.NewWriter(buf)
+	header := []string{"答卷ID", "用户名", "提交时间"}
+	if isQuiz {
+		header = append(header, "得分")
+	}
+	for _, head := range buildListHead(&surveySchema) {
+		header = append(header, head.Title)
+		header = append(header, lo.Map(head.OthersKey, func(o Other, _ int) string {
+			return fmt.Sprintf("%s-%s(自定义输入)", head.Title, o.Option)
+		})...)
+	}
+	if err := w.Write(header); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("写入导出文件失败")
+		return comm.CodeUnknownError
+	}
+
+	// 分批查询答卷并写入
+	count := 0
+	err = repo.NewResultRepo().ForSurvey(survey).FindInBatches(ctx, survey.ID, 500, func(list []*model.Result) error {
+		for _, res := range list {
+			answerMap, err := parseAnswerMap(res)
+			if err != nil {
+				nlog.Pick().WithContext(ctx).WithError(err).Errorf("答卷数据解析失败 ID:%d", res.ID)
+				continue
+			}
+			if err := revealSensitive(&surveySchema, answerMap, req.Reveal); err != nil {
+				nlog.Pick().WithContext(ctx).WithError(err).Errorf("敏感答案解密失败 ID:%d", res.ID)
+			}
+			record := []string{strconv.FormatInt(res.ID, 10), res.Username, res.CreatedAt.Format(time.DateTime)}
+			if isQuiz {
+				record = append(record, strconv.Itoa(int(res.Score)))
+			}
+			record = append(record, lo.Map(buildListRow(&surveySchema, answerMap), func(item comm.ResultItem, _ int) string {
+				return item.Answer
+			})...)
+			if err := w.Write(record); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("导出答卷失败")
+		return comm.CodeDatabaseError
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("写入导出文件失败")
+		return comm.CodeUnknownError
+	}
+
+	// 记录审计日志 记录失败时不返回导出内容
+	err = repo.NewAuditLogRepo().Record(ctx, repo.AuditEntry{
+		AdminID:    admin.ID,
+		Action:     comm.AuditActionResultExport,
+		TargetType: comm.AuditTargetSurvey,
+		TargetID:   survey.ID,
+		Detail: map[string]any{
+			"title":  survey.Title,
+			"locale": locale,
+			"reveal": req.Reveal,
+			"count":  count,
+		},
+		IP:        ctx.ClientIP(),
+		RequestID: requestid.Get(ctx),
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("记录审计日志失败")
+		return comm.CodeDatabaseError
+	}
+
+	e.Response.Filename = fmt.Sprintf("%s_%s.csv", survey.Title, time.Now().Format("20060102150405"))
+	e.Response.Content = buf.Bytes()
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (e *ExportApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&e.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfExport API执行入口
+func hfExport(ctx *gin.Context) {
+	api := &ExportApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			ctx.Header("Content-Disposition", "attachment; filename*=UTF-8''"+url.PathEscape(api.Response.Filename))
+			ctx.Data(http.StatusOK, "text/csv; charset=utf-8", api.Response.Content)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package result
 import (
 	"reflect"
 	"runtime"
-	"strings"
 
 	"github.com/bytedance/sonic"
 	"github.com/gin-gonic/gin"
@@ -35,9 +34,10 @@ type ListApi struct {
 
 type ListApiRequest struct {
 	Query struct {
-		SurveyID int64 `form:"survey_id" binding:"required,gte=1" desc:"问卷ID"`
-		Page     int   `form:"page" binding:"required,gte=1" desc:"页码"`
-		PageSize int   `form:"page_size" binding:"required,gte=1,lte=100" desc:"每页数量"`
+		SurveyID int64  `form:"survey_id" binding:"required,gte=1" desc:"问卷ID"`
+		Page     int    `form:"page" binding:"required,gte=1" desc:"页码"`
+		PageSize int    `form:"page_size" binding:"required,gte=1,lte=100" desc:"每页数量"`
+		Locale   string `form:"locale" binding:"omitempty,bcp47_language_tag" desc:"展示语言 按问卷启用的语言匹配 为空或未匹配时使用默认文本"`
 	}
 }
 
@@ -95,95 +95,33 @@ func (l *ListApi) Run(ctx *gin.Context) kit.Code {
 		return comm.CodeDataParseError
 	}
 
+	// 切换展示语言 按语言标识匹配问卷启用的语言 如en-US匹配en
+	locale := surveySchema.MatchLocale(req.Locale, "")
+	surveySchema.Localize(locale)
+
 	// 构建列表头
-	l.Response.ListHead = lo.Map(surveySchema.QuestionConf.Items, func(item schema.QuestionItem, _ int) QuestionItem {
-		// 自定义输入内容选项
-		othersKey := lo.FilterMap(item.Options, func(opt schema.Option, _ int) (Other, bool) {
-			return Other{
-				Key:    opt.OthersKey,
-				Option: opt.Text,
-			}, opt.Others
-		})
-		return QuestionItem{
-			ID:        item.ID,
-			Title:     item.Title,
-			Type:      string(item.Type),
-			OthersKey: othersKey,
-		}
-	})
+	l.Response.ListHead = buildListHead(&surveySchema)
 
 	// 查询答卷列表
-	list, total, err := repo.NewResultRepo().FindPage(ctx, req.SurveyID, req.Page, req.PageSize)
+	list, total, err := repo.NewResultRepo().ForSurvey(survey).FindPage(ctx, req.SurveyID, req.Page, req.PageSize)
 	if err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("查询答卷列表失败")
 		return comm.CodeDatabaseError
 	}
 	l.Response.Total = total
 
-	// 筛选选项类题目
-	optionQuestions := lo.Filter(surveySchema.QuestionConf.Items, func(item schema.QuestionItem, _ int) bool {
-		return item.IsOptionType()
-	})
-
-	// 构建题目选项映射 map[QuestionID]map[OptionID]OptionText
-	questionOptionMap := lo.SliceToMap(optionQuestions, func(item schema.QuestionItem) (string, map[string]string) {
-		return item.ID, lo.SliceToMap(item.Options, func(o schema.Option) (string, string) {
-			return o.ID, o.Text
-		})
-	})
-
 	// 构建响应数据
 	l.Response.ListBody = lo.Map(list, func(res *model.Result, _ int) []comm.ResultItem {
 		// 答卷数据反序列化
-		var resultItems []comm.ResultItem
-		if err := sonic.UnmarshalString(res.Data, &resultItems); err != nil {
+		answerMap, err := parseAnswerMap(res)
+		if err != nil {
 			nlog.Pick().WithContext(ctx).WithError(err).Errorf("答卷数据解析失败 ID:%d", res.ID)
 			return nil
 		}
-
-		// 构建题目答案映射 map[QuestionID]Answer
-		answerMap := lo.SliceToMap(resultItems, func(item comm.ResultItem) (string, string) {
-			return item.QuestionID, item.Answer
-		})
-
-		// 构建响应行数据
-		row := lo.FlatMap(surveySchema.QuestionConf.Items, func(item schema.QuestionItem, _ int) []comm.ResultItem {
-			val := answerMap[item.ID]
-			if item.IsOptionType() && val != "" {
-				// 选项类题目将选项ID转换为文本
-				if optMap, ok := questionOptionMap[item.ID]; ok {
-					selectedIDs := strings.Split(val, ",")
-					selectedTexts := lo.FilterMap(selectedIDs, func(id string, _ int) (string, bool) {
-						if text, ok := optMap[id]; ok {
-							return text, true
-						}
-						return "", false
-					})
-					val = strings.Join(selectedTexts, ",")
-				}
-			}
-
-			items := []comm.ResultItem{{
-				QuestionID: item.ID,
-				Answer:     val,
-			}}
-
-			// 自定义输入内容选项
-			if item.IsOptionType() {
-				others := lo.FilterMap(item.Options, func(opt schema.Option, _ int) (comm.ResultItem, bool) {
-					key := opt.OthersKey
-					return comm.ResultItem{
-						QuestionID: key,
-						Answer:     answerMap[key],
-					}, opt.Others
-				})
-				items = append(items, others...)
-			}
-
-			return items
-		})
-
-		return row
+		if err := revealSensitive(&surveySchema, answerMap, false); err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Errorf("敏感答案解密失败 ID:%d", res.ID)
+		}
+		return buildListRow(&surveySchema, answerMap)
 	})
 
 	return comm.CodeOK
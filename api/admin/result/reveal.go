@@ -0,0 +1,154 @@
+package result
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// RevealHandler API router注册点
+func RevealHandler() gin.HandlerFunc {
+	api := RevealApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfReveal).Pointer()).Name()] = api
+	return hfReveal
+}
+
+type RevealApi struct {
+	Info     struct{}          `name:"查看答卷敏感信息" desc:"解密并返回单份答卷中敏感信息题目的答案明文 仅超级管理员可用 每次查看均记录审计日志"`
+	Request  RevealApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response RevealApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type RevealApiRequest struct {
+	Body struct {
+		ResultID int64 `json:"result_id" binding:"required,gte=1" desc:"答卷ID"`
+	}
+}
+
+type RevealApiResponse struct {
+	List []comm.ResultItem `json:"list" desc:"敏感信息题目答案明文"`
+}
+
+// Run Api业务逻辑执行点
+func (r *RevealApi) Run(ctx *gin.Context) kit.Code {
+	req := r.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 查询答卷及问卷 答卷表中不存在时查询归档表
+	res, err := repo.NewResultRepo().FindByID(ctx, req.ResultID)
+	if err == nil && res == nil {
+		res, err = repo.NewResultRepo().Archive().FindByID(ctx, req.ResultID)
+	}
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询答卷失败")
+		return comm.CodeDatabaseError
+	}
+	if res == nil {
+		return comm.CodeDataNotFound
+	}
+	survey, err := repo.NewSurveyRepo().FindByID(ctx, res.SurveyID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
+		return comm.CodeDatabaseError
+	}
+	if survey == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 问卷结构反序列化
+	var surveySchema schema.SurveySchema
+	if err := sonic.UnmarshalString(survey.Schema, &surveySchema); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("问卷结构反序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 解密敏感答案
+	answerMap, err := parseAnswerMap(res)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Errorf("答卷数据解析失败 ID:%d", res.ID)
+		return comm.CodeDataParseError
+	}
+	sensitiveIDs := sensitiveAnswerIDs(&surveySchema, answerMap)
+	if err := revealSensitive(&surveySchema, answerMap, true); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Errorf("敏感答案解密失败 ID:%d", res.ID)
+		return comm.CodeDataParseError
+	}
+
+	// 记录审计日志 记录失败时不返回明文
+	err = repo.NewAuditLogRepo().Record(ctx, repo.AuditEntry{
+		AdminID:    admin.ID,
+		Action:     comm.AuditActionResultReveal,
+		TargetType: comm.AuditTargetSurvey,
+		TargetID:   survey.ID,
+		Detail: map[string]any{
+			"result_id":    res.ID,
+			"question_ids": sensitiveIDs,
+		},
+		IP:        ctx.ClientIP(),
+		RequestID: requestid.Get(ctx),
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("记录审计日志失败")
+		return comm.CodeDatabaseError
+	}
+
+	r.Response.List = lo.Map(sensitiveIDs, func(id string, _ int) comm.ResultItem {
+		return comm.ResultItem{
+			QuestionID: id,
+			Answer:     answerMap[id],
+		}
+	})
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (r *RevealApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&r.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfReveal API执行入口
+func hfReveal(ctx *gin.Context) {
+	api := &RevealApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
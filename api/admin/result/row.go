@@ -0,0 +1,101 @@
+package result
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+	"github.com/samber/lo"
+
+	"app/comm"
+	"app/dao/model"
+	"app/schema"
+)
+
+// parseAnswerMap 答卷数据反序列化 构建题目答案映射 map[QuestionID]Answer
+func parseAnswerMap(res *model.Result) (map[string]string, error) {
+	var resultItems []comm.ResultItem
+	if err := sonic.UnmarshalString(res.Data, &resultItems); err != nil {
+		return nil, err
+	}
+	return lo.SliceToMap(resultItems, func(item comm.ResultItem) (string, string) {
+		return item.QuestionID, item.Answer
+	}), nil
+}
+
+// maskedAnswer 敏感答案解密失败时的展示内容
+const maskedAnswer = "******"
+
+// sensitiveAnswerIDs 按题目顺序返回需解密及脱敏的已作答题目ID
+// 包括当前的敏感信息题目 及后续取消敏感标记但答案仍为加密存储的题目
+func sensitiveAnswerIDs(surveySchema *schema.SurveySchema, answerMap map[string]string) []string {
+	sensitiveIDs := lo.Keyify(surveySchema.SensitiveIDs())
+	return lo.FilterMap(surveySchema.QuestionConf.Items, func(item schema.QuestionItem, _ int) (string, bool) {
+		val := answerMap[item.ID]
+		_, sensitive := sensitiveIDs[item.ID]
+		return item.ID, val != "" && (sensitive || comm.IsEncrypted(val))
+	})
+}
+
+// revealSensitive 解密敏感信息题目答案 reveal=false时脱敏显示 解密失败的答案以掩码代替
+func revealSensitive(surveySchema *schema.SurveySchema, answerMap map[string]string, reveal bool) error {
+	var errs []error
+	for _, id := range sensitiveAnswerIDs(surveySchema, answerMap) {
+		val := answerMap[id]
+		plaintext, err := comm.AnswerKeyring.Decrypt(val)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("题目%s: %w", id, err))
+			answerMap[id] = maskedAnswer
+			continue
+		}
+		if !reveal {
+			plaintext = comm.MaskSensitive(plaintext)
+		}
+		answerMap[id] = plaintext
+	}
+	return errors.Join(errs...)
+}
+
+// buildListHead 构建答卷列表头
+func buildListHead(surveySchema *schema.SurveySchema) []QuestionItem {
+	return lo.Map(surveySchema.QuestionConf.Items, func(item schema.QuestionItem, _ int) QuestionItem {
+		// 自定义输入内容选项
+		othersKey := lo.FilterMap(item.Options, func(opt schema.Option, _ int) (Other, bool) {
+			return Other{
+				Key:    opt.OthersKey,
+				Option: opt.Text,
+			}, opt.Others
+		})
+		return QuestionItem{
+			ID:        item.ID,
+			Title:     item.Title,
+			Type:      string(item.Type),
+			OthersKey: othersKey,
+		}
+	})
+}
+
+// buildListRow 构建答卷行数据 与列表头一一对应
+func buildListRow(surveySchema *schema.SurveySchema, answerMap map[string]string) []comm.ResultItem {
+	return lo.FlatMap(surveySchema.QuestionConf.Items, func(item schema.QuestionItem, _ int) []comm.ResultItem {
+		// 选项类题目将选项ID转换为文本 (渲染答案引用)
+		items := []comm.ResultItem{{
+			QuestionID: item.ID,
+			Answer:     surveySchema.AnswerText(&item, answerMap[item.ID], answerMap),
+		}}
+
+		// 自定义输入内容选项
+		if item.IsOptionType() {
+			others := lo.FilterMap(item.Options, func(opt schema.Option, _ int) (comm.ResultItem, bool) {
+				key := opt.OthersKey
+				return comm.ResultItem{
+					QuestionID: key,
+					Answer:     answerMap[key],
+				}, opt.Others
+			})
+			items = append(items, others...)
+		}
+
+		return items
+	})
+}
@@ -0,0 +1,213 @@
+package result
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// ScoreHandler API router注册点
+func ScoreHandler() gin.HandlerFunc {
+	api := ScoreApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfScore).Pointer()).Name()] = api
+	return hfScore
+}
+
+type ScoreApi struct {
+	Info     struct{}         `name:"获取测验成绩报告" desc:"获取测验得分分布及各题正确率 仅测验类问卷可用"`
+	Request  ScoreApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response ScoreApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type ScoreApiRequest struct {
+	Query struct {
+		SurveyID int64 `form:"survey_id" binding:"required,gte=1" desc:"问卷ID"`
+	}
+}
+
+type ScoreApiResponse struct {
+	SubmitCount  int64           `json:"submit_count" desc:"提交总数"`
+	FullScore    int             `json:"full_score" desc:"测验总分"`
+	AvgScore     float64         `json:"avg_score" desc:"平均分"`
+	MaxScore     int32           `json:"max_score" desc:"最高分"`
+	MinScore     int32           `json:"min_score" desc:"最低分"`
+	Distribution []ScoreBucket   `json:"distribution" desc:"得分分布"`
+	Questions    []QuestionScore `json:"questions" desc:"各题正确率"`
+}
+
+type ScoreBucket struct {
+	Score int32 `json:"score" desc:"得分"`
+	Count int64 `json:"count" desc:"人数"`
+}
+
+type QuestionScore struct {
+	ID           string            `json:"id" desc:"题目ID"`
+	Title        string            `json:"title" desc:"题目标题"`
+	Type         comm.QuestionType `json:"type" desc:"题型"`
+	FullScore    int               `json:"full_score" desc:"满分"`
+	AnswerCount  int64             `json:"answer_count" desc:"作答人数"`
+	CorrectCount int64             `json:"correct_count" desc:"答对人数"`
+	CorrectRate  float64           `json:"correct_rate" desc:"正确率 答对人数/提交总数"`
+	AvgScore     float64           `json:"avg_score" desc:"平均得分"`
+}
+
+// Run Api业务逻辑执行点
+func (s *ScoreApi) Run(ctx *gin.Context) kit.Code {
+	req := s.Request.Query
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询问卷
+	survey, err := repo.NewSurveyRepo().FindByID(ctx, req.SurveyID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
+		return comm.CodeDatabaseError
+	}
+	if survey == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 校验权限
+	if survey.AdminID != admin.ID && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 仅测验类问卷可用
+	if comm.SurveyType(survey.Type) != comm.SurveyTypeQuiz {
+		return comm.CodeParameterInvalid
+	}
+
+	// 问卷结构反序列化
+	var surveySchema schema.SurveySchema
+	if err := sonic.UnmarshalString(survey.Schema, &surveySchema); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("问卷结构反序列化失败")
+		return comm.CodeDataParseError
+	}
+	s.Response.FullScore = surveySchema.FullScore()
+
+	// 查询得分分布
+	scoreList, err := repo.NewResultRepo().ForSurvey(survey).CountGroupByScore(ctx, survey.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询得分分布失败")
+		return comm.CodeDatabaseError
+	}
+	s.Response.Distribution = lo.Map(scoreList, func(item repo.ScoreCount, _ int) ScoreBucket {
+		return ScoreBucket{
+			Score: item.Score,
+			Count: item.Count,
+		}
+	})
+	if len(scoreList) > 0 {
+		s.Response.MinScore = scoreList[0].Score
+		s.Response.MaxScore = scoreList[len(scoreList)-1].Score
+	}
+	s.Response.SubmitCount = lo.SumBy(scoreList, func(item repo.ScoreCount) int64 {
+		return item.Count
+	})
+	if s.Response.SubmitCount > 0 {
+		totalScore := lo.SumBy(scoreList, func(item repo.ScoreCount) int64 {
+			return int64(item.Score) * item.Count
+		})
+		s.Response.AvgScore = float64(totalScore) / float64(s.Response.SubmitCount)
+	}
+
+	// 按当前答案配置逐份判分 统计各题正确率
+	gradable := lo.Filter(surveySchema.QuestionConf.Items, func(item schema.QuestionItem, _ int) bool {
+		return item.IsGradable()
+	})
+	questionMap := lo.SliceToMap(gradable, func(item schema.QuestionItem) (string, *QuestionScore) {
+		return item.ID, &QuestionScore{
+			ID:        item.ID,
+			Title:     item.Title,
+			Type:      item.Type,
+			FullScore: item.FullScore(),
+		}
+	})
+	scoreSum := make(map[string]int64)
+	err = repo.NewResultRepo().ForSurvey(survey).FindInBatches(ctx, survey.ID, 500, func(list []*model.Result) error {
+		for _, res := range list {
+			answerMap, err := parseAnswerMap(res)
+			if err != nil {
+				nlog.Pick().WithContext(ctx).WithError(err).Errorf("答卷数据解析失败 ID:%d", res.ID)
+				continue
+			}
+			// 敏感信息题目答案加密存储 解密后判分
+			if err := revealSensitive(&surveySchema, answerMap, true); err != nil {
+				nlog.Pick().WithContext(ctx).WithError(err).Errorf("敏感答案解密失败 ID:%d", res.ID)
+			}
+			_, grades := surveySchema.Grade(answerMap)
+			for _, g := range grades {
+				qs := questionMap[g.QuestionID]
+				if g.Answered {
+					qs.AnswerCount++
+				}
+				if g.Correct {
+					qs.CorrectCount++
+				}
+				scoreSum[g.QuestionID] += int64(g.Score)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询答卷列表失败")
+		return comm.CodeDatabaseError
+	}
+
+	// 构建响应数据
+	s.Response.Questions = lo.Map(gradable, func(item schema.QuestionItem, _ int) QuestionScore {
+		qs := questionMap[item.ID]
+		if s.Response.SubmitCount > 0 {
+			qs.CorrectRate = float64(qs.CorrectCount) / float64(s.Response.SubmitCount)
+			qs.AvgScore = float64(scoreSum[item.ID]) / float64(s.Response.SubmitCount)
+		}
+		return *qs
+	})
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (s *ScoreApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&s.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfScore API执行入口
+func hfScore(ctx *gin.Context) {
+	api := &ScoreApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
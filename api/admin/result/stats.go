@@ -34,7 +34,8 @@ type StatsApi struct {
 
 type StatsApiRequest struct {
 	Query struct {
-		SurveyID int64 `form:"survey_id" binding:"required,gte=1" desc:"问卷ID"`
+		SurveyID int64  `form:"survey_id" binding:"required,gte=1" desc:"问卷ID"`
+		Locale   string `form:"locale" binding:"omitempty,bcp47_language_tag" desc:"展示语言 按问卷启用的语言匹配 为空或未匹配时使用默认文本"`
 	}
 }
 
@@ -51,9 +52,11 @@ type StatsItem struct {
 }
 
 type Option struct {
-	ID    string `json:"id" desc:"选项ID"`
-	Text  string `json:"text" desc:"选项文本"`
-	Count int32  `json:"count" desc:"数量"`
+	ID       string `json:"id" desc:"选项ID"`
+	Text     string `json:"text" desc:"选项文本"`
+	Count    int32  `json:"count" desc:"数量"`
+	ParentID string `json:"parent_id,omitempty" desc:"上级节点ID 仅级联题返回"`
+	Level    int    `json:"level,omitempty" desc:"节点层级 从1开始 仅级联题返回"`
 }
 
 // Run Api业务逻辑执行点
@@ -88,6 +91,10 @@ func (s *StatsApi) Run(ctx *gin.Context) kit.Code {
 		return comm.CodeDataParseError
 	}
 
+	// 切换展示语言 按语言标识匹配问卷启用的语言 如en-US匹配en
+	locale := surveySchema.MatchLocale(req.Locale, "")
+	surveySchema.Localize(locale)
+
 	// 查询统计数据列表
 	statsList, err := repo.NewStatsRepo().FindListBySurveyID(ctx, survey.ID)
 	if err != nil {
@@ -96,7 +103,7 @@ func (s *StatsApi) Run(ctx *gin.Context) kit.Code {
 	}
 
 	// 查询提交总数
-	totalCount, err := repo.NewResultRepo().CountBySurveyID(ctx, survey.ID)
+	totalCount, err := repo.NewResultRepo().ForSurvey(survey).CountBySurveyID(ctx, survey.ID)
 	if err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("查询提交总数失败")
 		return comm.CodeDatabaseError
@@ -112,9 +119,9 @@ func (s *StatsApi) Run(ctx *gin.Context) kit.Code {
 		statsMap[st.QuestionID][st.OptionID] = st.Count
 	}
 
-	// 筛选选项类题目
+	// 筛选选项类及级联题目
 	optionQuestions := lo.Filter(surveySchema.QuestionConf.Items, func(item schema.QuestionItem, _ int) bool {
-		return item.IsOptionType()
+		return item.IsOptionType() || item.IsCascadeType()
 	})
 
 	// 构建响应数据
@@ -123,6 +130,16 @@ func (s *StatsApi) Run(ctx *gin.Context) kit.Code {
 
 		// 处理现有选项
 		processedOpts := make(map[string]bool)
+		for _, node := range schema.FlattenCascade(item.CascadeOptions) {
+			options = append(options, Option{
+				ID:       node.ID,
+				Text:     node.Text,
+				Count:    statsMap[item.ID][node.ID],
+				ParentID: node.ParentID,
+				Level:    node.Level,
+			})
+			processedOpts[node.ID] = true
+		}
 		for _, opt := range item.Options {
 			count := int32(0)
 			if optCountMap, ok := statsMap[item.ID]; ok {
@@ -0,0 +1,167 @@
+package survey
+
+import (
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/repo"
+)
+
+// AllowlistProgressHandler API router注册点
+func AllowlistProgressHandler() gin.HandlerFunc {
+	api := AllowlistProgressApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfAllowlistProgress).Pointer()).Name()] = api
+	return hfAllowlistProgress
+}
+
+type AllowlistProgressApi struct {
+	Info     struct{}                     `name:"获取答题名单完成情况" desc:"统计答题名单内用户的提交情况 可按是否已提交筛选"`
+	Request  AllowlistProgressApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response AllowlistProgressApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type AllowlistProgressApiRequest struct {
+	Query struct {
+		SurveyID int64                `form:"survey_id" binding:"required,gte=1" desc:"问卷ID"`
+		Status   repo.AllowlistFilter `form:"status" binding:"oneof=0 1 2" desc:"提交状态筛选 0-全部 1-已提交 2-未提交"`
+		Page     int                  `form:"page" binding:"required,gte=1" desc:"页码"`
+		PageSize int                  `form:"page_size" binding:"required,gte=1,lte=100" desc:"每页数量"`
+	}
+}
+
+type AllowlistProgressApiResponse struct {
+	Page      int                     `json:"page" desc:"页码"`
+	PageSize  int                     `json:"page_size" desc:"每页数量"`
+	List      []AllowlistProgressItem `json:"list" desc:"名单列表"`
+	Total     int64                   `json:"total" desc:"符合筛选条件的数量"`
+	AllCount  int64                   `json:"all_count" desc:"名单总人数"`
+	Submitted int64                   `json:"submitted" desc:"已提交人数"`
+	Pending   int64                   `json:"pending" desc:"未提交人数"`
+}
+
+type AllowlistProgressItem struct {
+	Username     string `json:"username" desc:"用户名"`
+	Remark       string `json:"remark" desc:"备注"`
+	Submitted    bool   `json:"submitted" desc:"是否已提交"`
+	Count        int64  `json:"count" desc:"提交次数"`
+	LastSubmitAt string `json:"last_submit_at" desc:"最近提交时间 未提交时为空"`
+}
+
+// Run Api业务逻辑执行点
+func (a *AllowlistProgressApi) Run(ctx *gin.Context) kit.Code {
+	req := a.Request.Query
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询问卷
+	survey, err := repo.NewSurveyRepo().FindByID(ctx, req.SurveyID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
+		return comm.CodeDatabaseError
+	}
+	if survey == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 校验权限
+	if survey.AdminID != admin.ID && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 统计完成情况
+	allowlistRepo := repo.NewSurveyAllowlistRepo()
+	allCount, submitted, err := allowlistRepo.CountProgress(ctx, survey)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("统计答题名单完成情况失败")
+		return comm.CodeDatabaseError
+	}
+
+	// 分页查询名单
+	list, total, err := allowlistRepo.FindPage(ctx, survey, req.Status, req.Page, req.PageSize)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询答题名单失败")
+		return comm.CodeDatabaseError
+	}
+
+	// 查询名单用户的提交情况
+	statsMap := make(map[string]repo.UserSubmitStats)
+	if len(list) > 0 {
+		stats, err := repo.NewResultRepo().ForSurvey(survey).StatsByUsernames(ctx, survey.ID, lo.Map(list, func(item *model.SurveyAllowlist, _ int) string {
+			return item.Username
+		}))
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询用户提交情况失败")
+			return comm.CodeDatabaseError
+		}
+		statsMap = lo.KeyBy(stats, func(item repo.UserSubmitStats) string {
+			return item.Username
+		})
+	}
+
+	// 构建响应数据
+	a.Response = AllowlistProgressApiResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		List: lo.Map(list, func(item *model.SurveyAllowlist, _ int) AllowlistProgressItem {
+			res := AllowlistProgressItem{
+				Username: item.Username,
+				Remark:   item.Remark,
+			}
+			if st, ok := statsMap[item.Username]; ok {
+				res.Submitted = true
+				res.Count = st.Count
+				res.LastSubmitAt = st.LastSubmitAt.Format(time.DateTime)
+			}
+			return res
+		}),
+		Total:     total,
+		AllCount:  allCount,
+		Submitted: submitted,
+		Pending:   allCount - submitted,
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (a *AllowlistProgressApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&a.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfAllowlistProgress API执行入口
+func hfAllowlistProgress(ctx *gin.Context) {
+	api := &AllowlistProgressApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
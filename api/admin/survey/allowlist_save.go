@@ -0,0 +1,158 @@
+package survey
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/query"
+	"app/dao/repo"
+)
+
+// AllowlistSaveHandler API router注册点
+func AllowlistSaveHandler() gin.HandlerFunc {
+	api := AllowlistSaveApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfAllowlistSave).Pointer()).Name()] = api
+	return hfAllowlistSave
+}
+
+type AllowlistSaveApi struct {
+	Info     struct{}                 `name:"保存问卷答题名单" desc:"上传问卷答题名单 问卷开启use_allowlist时仅名单内用户可访问及提交"`
+	Request  AllowlistSaveApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response AllowlistSaveApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type AllowlistSaveApiRequest struct {
+	Body struct {
+		SurveyID int64           `json:"survey_id" binding:"required,gte=1" desc:"问卷ID"`
+		Replace  bool            `json:"replace" desc:"是否覆盖现有名单 false时追加 用户名已存在时更新备注"`
+		List     []AllowlistItem `json:"list" binding:"max=5000,dive" desc:"名单列表"`
+	}
+}
+
+type AllowlistItem struct {
+	Username string `json:"username" binding:"required,max=16" desc:"用户名"`
+	Remark   string `json:"remark" binding:"max=64" desc:"备注 如姓名、班级"`
+}
+
+type AllowlistSaveApiResponse struct {
+	Total int64 `json:"total" desc:"保存后名单总人数"`
+}
+
+// Run Api业务逻辑执行点
+func (a *AllowlistSaveApi) Run(ctx *gin.Context) kit.Code {
+	req := a.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询问卷
+	survey, err := repo.NewSurveyRepo().FindByID(ctx, req.SurveyID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
+		return comm.CodeDatabaseError
+	}
+	if survey == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 校验权限
+	if survey.AdminID != admin.ID && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 按用户名去重 重复时保留最后一条
+	entries := lo.UniqBy(lo.Reverse(lo.Map(req.List, func(item AllowlistItem, _ int) repo.AllowlistEntry {
+		return repo.AllowlistEntry{
+			Username: item.Username,
+			Remark:   item.Remark,
+		}
+	})), func(entry repo.AllowlistEntry) string {
+		return entry.Username
+	})
+
+	// 事务 清空名单 -> 保存名单 -> 记录审计日志
+	var total int64
+	err = repo.Transaction(func(tx *query.Query) error {
+		allowlistRepo := repo.NewSurveyAllowlistRepo(tx)
+		if req.Replace {
+			if _, err := allowlistRepo.DeleteBySurveyID(ctx, survey.ID); err != nil {
+				return err
+			}
+		}
+		if len(entries) > 0 {
+			if err := allowlistRepo.BatchSave(ctx, survey.ID, entries); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		total, err = allowlistRepo.CountBySurveyID(ctx, survey.ID)
+		if err != nil {
+			return err
+		}
+
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionAllowlistSave,
+			TargetType: comm.AuditTargetSurvey,
+			TargetID:   survey.ID,
+			Detail: map[string]any{
+				"title":   survey.Title,
+				"replace": req.Replace,
+				"count":   len(entries),
+				"total":   total,
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("保存问卷答题名单失败")
+		return comm.CodeDatabaseError
+	}
+
+	a.Response.Total = total
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (a *AllowlistSaveApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&a.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfAllowlistSave API执行入口
+func hfAllowlistSave(ctx *gin.Context) {
+	api := &AllowlistSaveApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
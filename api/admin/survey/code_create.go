@@ -0,0 +1,125 @@
+package survey
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/query"
+	"app/dao/repo"
+)
+
+// CodeCreateHandler API router注册点
+func CodeCreateHandler() gin.HandlerFunc {
+	api := CodeCreateApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfCodeCreate).Pointer()).Name()] = api
+	return hfCodeCreate
+}
+
+type CodeCreateApi struct {
+	Info     struct{}              `name:"生成问卷访问码" desc:"为问卷批量生成一次性访问码 问卷access_mode=code时生效 生成后通过导出接口获取"`
+	Request  CodeCreateApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response CodeCreateApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type CodeCreateApiRequest struct {
+	Body struct {
+		SurveyID int64 `json:"survey_id" binding:"required,gte=1" desc:"问卷ID"`
+		Count    int   `json:"count" binding:"required,gte=1,lte=1000" desc:"生成数量"`
+	}
+}
+
+type CodeCreateApiResponse struct {
+	Count int `json:"count" desc:"生成数量"`
+}
+
+// Run Api业务逻辑执行点
+func (c *CodeCreateApi) Run(ctx *gin.Context) kit.Code {
+	req := c.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询问卷
+	survey, err := repo.NewSurveyRepo().FindByID(ctx, req.SurveyID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
+		return comm.CodeDatabaseError
+	}
+	if survey == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 校验权限
+	if survey.AdminID != admin.ID && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 事务 创建访问码 -> 记录审计日志
+	codes := comm.GenerateAccessCodes(req.Count)
+	err = repo.Transaction(func(tx *query.Query) error {
+		if err := repo.NewSurveyAccessCodeRepo(tx).BatchCreate(ctx, survey.ID, codes); err != nil {
+			return err
+		}
+
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionAccessCodeCreate,
+			TargetType: comm.AuditTargetSurvey,
+			TargetID:   survey.ID,
+			Detail: map[string]any{
+				"title": survey.Title,
+				"count": len(codes),
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("生成问卷访问码失败")
+		return comm.CodeDatabaseError
+	}
+
+	c.Response.Count = len(codes)
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (c *CodeCreateApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&c.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfCodeCreate API执行入口
+func hfCodeCreate(ctx *gin.Context) {
+	api := &CodeCreateApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
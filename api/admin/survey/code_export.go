@@ -0,0 +1,166 @@
+package survey
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/repo"
+)
+
+// CodeExportHandler API router注册点
+func CodeExportHandler() gin.HandlerFunc {
+	api := CodeExportApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfCodeExport).Pointer()).Name()] = api
+	return hfCodeExport
+}
+
+type CodeExportApi struct {
+	Info     struct{}              `name:"导出问卷访问码" desc:"导出问卷全部一次性访问码及使用情况为CSV文件 成功时直接返回文件内容"`
+	Request  CodeExportApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response CodeExportApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type CodeExportApiRequest struct {
+	Query struct {
+		SurveyID int64 `form:"survey_id" binding:"required,gte=1" desc:"问卷ID"`
+	}
+}
+
+type CodeExportApiResponse struct {
+	Filename string `json:"-"`
+	Content  []byte `json:"-"`
+}
+
+// Run Api业务逻辑执行点
+func (c *CodeExportApi) Run(ctx *gin.Context) kit.Code {
+	req := c.Request.Query
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询问卷
+	survey, err := repo.NewSurveyRepo().FindByID(ctx, req.SurveyID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
+		return comm.CodeDatabaseError
+	}
+	if survey == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 校验权限
+	if survey.AdminID != admin.ID && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 构建表头
+	buf := &bytes.Buffer{}
+	buf.WriteString("\xEF\xBB\xBF") // UTF-8 BOM 兼容 Excel
+	w := csv.NewWriter(buf)
+	if err := w.Write([]string{"访问码", "状态", "答卷ID", "使用时间"}); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("写入导出文件失败")
+		return comm.CodeUnknownError
+	}
+
+	// 分批查询访问码并写入
+	count := 0
+	err = repo.NewSurveyAccessCodeRepo().FindInBatches(ctx, survey.ID, 500, func(list []*model.SurveyAccessCode) error {
+		for _, item := range list {
+			record := []string{item.Code, "未使用", "", ""}
+			if item.UsedAt > 0 {
+				record = []string{
+					item.Code,
+					"已使用",
+					strconv.FormatInt(item.ResultID, 10),
+					time.UnixMilli(item.UsedAt).Format(time.DateTime),
+				}
+			}
+			if err := w.Write(record); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("导出问卷访问码失败")
+		return comm.CodeDatabaseError
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("写入导出文件失败")
+		return comm.CodeUnknownError
+	}
+
+	// 记录审计日志 记录失败时不返回导出内容
+	err = repo.NewAuditLogRepo().Record(ctx, repo.AuditEntry{
+		AdminID:    admin.ID,
+		Action:     comm.AuditActionAccessCodeExport,
+		TargetType: comm.AuditTargetSurvey,
+		TargetID:   survey.ID,
+		Detail: map[string]any{
+			"title": survey.Title,
+			"count": count,
+		},
+		IP:        ctx.ClientIP(),
+		RequestID: requestid.Get(ctx),
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("记录审计日志失败")
+		return comm.CodeDatabaseError
+	}
+
+	c.Response.Filename = fmt.Sprintf("%s_访问码_%s.csv", survey.Title, time.Now().Format("20060102150405"))
+	c.Response.Content = buf.Bytes()
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (c *CodeExportApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&c.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfCodeExport API执行入口
+func hfCodeExport(ctx *gin.Context) {
+	api := &CodeExportApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			ctx.Header("Content-Disposition", "attachment; filename*=UTF-8''"+url.PathEscape(api.Response.Filename))
+			ctx.Data(http.StatusOK, "text/csv; charset=utf-8", api.Response.Content)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
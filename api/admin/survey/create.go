@@ -5,6 +5,7 @@ import (
 	"runtime"
 
 	"github.com/bytedance/sonic"
+	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/samber/lo"
@@ -15,6 +16,7 @@ import (
 	"github.com/zjutjh/mygo/swagger"
 
 	"app/comm"
+	"app/dao/cache"
 	"app/dao/model"
 	"app/dao/query"
 	"app/dao/repo"
@@ -36,7 +38,7 @@ type CreateApi struct {
 
 type CreateApiRequest struct {
 	Body struct {
-		Type   comm.SurveyType     `json:"type" binding:"required,oneof=1 2" desc:"问卷类型 1-问卷 2-投票"`
+		Type   comm.SurveyType     `json:"type" binding:"required,oneof=1 2 3" desc:"问卷类型 1-问卷 2-投票 3-测验"`
 		Schema schema.SurveySchema `json:"schema" binding:"required" desc:"问卷结构"`
 	}
 }
@@ -53,7 +55,14 @@ func (c *CreateApi) Run(ctx *gin.Context) kit.Code {
 		return comm.CodeNotLoggedIn
 	}
 
+	// 填充级联数据集
+	if err := repo.NewCascadeDatasetRepo().FillSchema(ctx, &req.Schema); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询级联数据集失败")
+		return comm.CodeDatabaseError
+	}
+
 	// 问卷结构校验
+	req.Schema.AdaptTo(req.Type)
 	if err := req.Schema.NormalizeAndVerify(); err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Warn("问卷结构校验失败")
 		return comm.CodeParameterInvalid
@@ -66,7 +75,7 @@ func (c *CreateApi) Run(ctx *gin.Context) kit.Code {
 		return comm.CodeDataParseError
 	}
 
-	// 事务 创建问卷 -> 初始化统计数据
+	// 事务 创建问卷 -> 初始化统计数据 -> 记录审计日志
 	survey := &model.Survey{
 		AdminID: admin.ID,
 		Title:   req.Schema.BannerConf.TitleConf.MainTitle,
@@ -83,14 +92,11 @@ func (c *CreateApi) Run(ctx *gin.Context) kit.Code {
 
 		// 初始化统计数据
 		statsList := lo.FlatMap(req.Schema.QuestionConf.Items, func(item schema.QuestionItem, _ int) []*model.Stats {
-			if !item.IsOptionType() {
-				return nil
-			}
-			return lo.Map(item.Options, func(option schema.Option, _ int) *model.Stats {
+			return lo.Map(item.StatsOptionIDs(), func(optionID string, _ int) *model.Stats {
 				return &model.Stats{
 					SurveyID:   survey.ID,
 					QuestionID: item.ID,
-					OptionID:   option.ID,
+					OptionID:   optionID,
 				}
 			})
 		})
@@ -100,12 +106,34 @@ func (c *CreateApi) Run(ctx *gin.Context) kit.Code {
 			}
 		}
 
-		return nil
+		// 记录审计日志
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionSurveyCreate,
+			TargetType: comm.AuditTargetSurvey,
+			TargetID:   survey.ID,
+			Detail: map[string]any{
+				"title": survey.Title,
+				"type":  req.Type,
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
 	}); err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("创建问卷失败")
 		return comm.CodeDatabaseError
 	}
 
+	// 加入问卷访问路径过滤器
+	if err := cache.NewSurveyCache().AddPath(ctx, survey.Path); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("通知新建问卷访问路径失败")
+	}
+
+	// 标记读主库 避免随后的列表查询读到从库未同步的数据
+	if err := cache.NewPrimaryPinCache().Pin(ctx, admin.ID); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("设置读主库标记失败")
+	}
+
 	return comm.CodeOK
 }
 
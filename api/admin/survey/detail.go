@@ -42,6 +42,7 @@ type DetailApiResponse struct {
 	Path      string              `json:"path" desc:"访问路径"`
 	Schema    schema.SurveySchema `json:"schema" desc:"问卷结构"`
 	Status    comm.SurveyStatus   `json:"status" desc:"状态 1-未发布 2-已发布"`
+	Frozen    bool                `json:"frozen" desc:"是否暂停提交"`
 	CreatedAt string              `json:"created_at" desc:"创建时间"`
 	UpdatedAt string              `json:"updated_at" desc:"更新时间"`
 }
@@ -74,6 +75,7 @@ func (d *DetailApi) Run(ctx *gin.Context) kit.Code {
 		Path:      survey.Path,
 		Schema:    schema,
 		Status:    comm.SurveyStatus(survey.Status),
+		Frozen:    survey.FrozenAt > 0,
 		CreatedAt: survey.CreatedAt.Format(time.DateTime),
 		UpdatedAt: survey.UpdatedAt.Format(time.DateTime),
 	}
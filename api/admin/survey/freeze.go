@@ -0,0 +1,138 @@
+package survey
+
+import (
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/query"
+	"app/dao/repo"
+)
+
+// FreezeHandler API router注册点
+func FreezeHandler() gin.HandlerFunc {
+	api := FreezeApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfFreeze).Pointer()).Name()] = api
+	return hfFreeze
+}
+
+type FreezeApi struct {
+	Info     struct{}          `name:"暂停/恢复问卷提交" desc:"暂停后问卷保持发布状态 可正常查看 但拒绝新的提交 用于排查刷票等异常"`
+	Request  FreezeApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response FreezeApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type FreezeApiRequest struct {
+	Body struct {
+		ID     int64 `json:"id" binding:"required,gte=1" desc:"问卷ID"`
+		Frozen bool  `json:"frozen" desc:"true-暂停提交 false-恢复提交"`
+	}
+}
+
+type FreezeApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (f *FreezeApi) Run(ctx *gin.Context) kit.Code {
+	req := f.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 查询问卷
+	survey, err := repo.NewSurveyRepo().FindByID(ctx, req.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
+		return comm.CodeDatabaseError
+	}
+	if survey == nil {
+		return comm.CodeDataNotFound
+	}
+
+	// 权限校验
+	if survey.AdminID != admin.ID && admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	frozenAt := int64(0)
+	if req.Frozen {
+		frozenAt = time.Now().UnixMilli()
+	}
+
+	// 事务 更新暂停提交时间 -> 记录审计日志
+	err = repo.Transaction(func(tx *query.Query) error {
+		if _, err := repo.NewSurveyRepo(tx).UpdateFrozenAt(ctx, req.ID, frozenAt); err != nil {
+			return err
+		}
+
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionSurveyFreeze,
+			TargetType: comm.AuditTargetSurvey,
+			TargetID:   survey.ID,
+			Detail: map[string]any{
+				"title":  survey.Title,
+				"before": survey.FrozenAt > 0,
+				"after":  req.Frozen,
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("更新问卷暂停提交状态失败")
+		return comm.CodeDatabaseError
+	}
+
+	// 删除问卷缓存 使提交接口立即感知暂停状态
+	if err := cache.NewSurveyCache().Del(ctx, survey.Path); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("删除问卷缓存失败")
+	}
+
+	// 标记读主库 避免随后的列表查询读到从库未同步的数据
+	if err := cache.NewPrimaryPinCache().Pin(ctx, admin.ID); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("设置读主库标记失败")
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (f *FreezeApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&f.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfFreeze API执行入口
+func hfFreeze(ctx *gin.Context) {
+	api := &FreezeApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -35,7 +35,7 @@ type ListApiRequest struct {
 	Query struct {
 		Page     int               `form:"page" binding:"required,gte=1" desc:"页码"`
 		PageSize int               `form:"page_size" binding:"required,gte=1,lte=100" desc:"每页数量"`
-		Type     comm.SurveyType   `form:"type" binding:"omitempty,oneof=1 2" desc:"问卷类型"`
+		Type     comm.SurveyType   `form:"type" binding:"omitempty,oneof=1 2 3" desc:"问卷类型 1-问卷 2-投票 3-测验"`
 		Status   comm.SurveyStatus `form:"status" binding:"omitempty,oneof=1 2" desc:"状态 1-未发布 2-已发布"`
 		Keyword  string            `form:"keyword" binding:"omitempty,max=64" desc:"搜索关键词"`
 	}
@@ -55,6 +55,7 @@ type SurveyItem struct {
 	Type      comm.SurveyType   `json:"type" desc:"问卷类型"`
 	Path      string            `json:"path" desc:"访问路径"`
 	Status    comm.SurveyStatus `json:"status" desc:"状态 1-未发布 2-已发布"`
+	Frozen    bool              `json:"frozen" desc:"是否暂停提交"`
 	CreatedAt string            `json:"created_at" desc:"创建时间"`
 	UpdatedAt string            `json:"updated_at" desc:"更新时间"`
 }
@@ -113,6 +114,7 @@ func (l *ListApi) Run(ctx *gin.Context) kit.Code {
 			Type:      comm.SurveyType(item.Type),
 			Path:      item.Path,
 			Status:    comm.SurveyStatus(item.Status),
+			Frozen:    item.FrozenAt > 0,
 			CreatedAt: item.CreatedAt.Format(time.DateTime),
 			UpdatedAt: item.UpdatedAt.Format(time.DateTime),
 		}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"runtime"
 
+	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
 	"github.com/zjutjh/mygo/foundation/reply"
 	"github.com/zjutjh/mygo/jwt"
@@ -13,6 +14,7 @@ import (
 
 	"app/comm"
 	"app/dao/cache"
+	"app/dao/query"
 	"app/dao/repo"
 )
 
@@ -63,8 +65,27 @@ func (s *StatusApi) Run(ctx *gin.Context) kit.Code {
 		return comm.CodePermissionDenied
 	}
 
-	// 更新问卷状态
-	if _, err := repo.NewSurveyRepo().UpdateStatus(ctx, req.ID, req.Status); err != nil {
+	// 事务 更新问卷状态 -> 记录审计日志
+	err = repo.Transaction(func(tx *query.Query) error {
+		if _, err := repo.NewSurveyRepo(tx).UpdateStatus(ctx, req.ID, req.Status); err != nil {
+			return err
+		}
+
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionSurveyStatus,
+			TargetType: comm.AuditTargetSurvey,
+			TargetID:   survey.ID,
+			Detail: map[string]any{
+				"title":  survey.Title,
+				"before": survey.Status,
+				"after":  req.Status,
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
+	})
+	if err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("更新问卷状态失败")
 		return comm.CodeDatabaseError
 	}
@@ -74,6 +95,11 @@ func (s *StatusApi) Run(ctx *gin.Context) kit.Code {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("删除问卷缓存失败")
 	}
 
+	// 标记读主库 避免随后的列表查询读到从库未同步的数据
+	if err := cache.NewPrimaryPinCache().Pin(ctx, admin.ID); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("设置读主库标记失败")
+	}
+
 	return comm.CodeOK
 }
 
@@ -5,6 +5,7 @@ import (
 	"runtime"
 
 	"github.com/bytedance/sonic"
+	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
 	"github.com/samber/lo"
 	"github.com/zjutjh/mygo/foundation/reply"
@@ -53,12 +54,6 @@ func (u *UpdateApi) Run(ctx *gin.Context) kit.Code {
 		return comm.CodeNotLoggedIn
 	}
 
-	// 问卷结构校验
-	if err := req.Schema.NormalizeAndVerify(); err != nil {
-		nlog.Pick().WithContext(ctx).WithError(err).Warn("问卷结构校验失败")
-		return comm.CodeParameterInvalid
-	}
-
 	// 查询旧问卷
 	oldSurvey, err := repo.NewSurveyRepo().FindByID(ctx, req.ID)
 	if err != nil {
@@ -74,6 +69,19 @@ func (u *UpdateApi) Run(ctx *gin.Context) kit.Code {
 		return comm.CodePermissionDenied
 	}
 
+	// 填充级联数据集
+	if err := repo.NewCascadeDatasetRepo().FillSchema(ctx, &req.Schema); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询级联数据集失败")
+		return comm.CodeDatabaseError
+	}
+
+	// 问卷结构校验
+	req.Schema.AdaptTo(comm.SurveyType(oldSurvey.Type))
+	if err := req.Schema.NormalizeAndVerify(); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("问卷结构校验失败")
+		return comm.CodeParameterInvalid
+	}
+
 	// 旧问卷结构反序列化
 	var oldSchema schema.SurveySchema
 	if err := sonic.UnmarshalString(oldSurvey.Schema, &oldSchema); err != nil {
@@ -94,28 +102,24 @@ func (u *UpdateApi) Run(ctx *gin.Context) kit.Code {
 				return comm.CodeParameterInvalid
 			}
 
-			// 选项类题型 检查新增选项
-			if newItem.IsOptionType() {
-				oldOptionMap := lo.KeyBy(oldItem.Options, func(opt schema.Option) string {
-					return opt.ID
-				})
-				for _, newOpt := range newItem.Options {
-					if _, exists := oldOptionMap[newOpt.ID]; !exists {
-						newStatsList = append(newStatsList, &model.Stats{
-							SurveyID:   oldSurvey.ID,
-							QuestionID: newItem.ID,
-							OptionID:   newOpt.ID,
-						})
-					}
+			// 选项类及级联题型 检查新增选项
+			oldOptionIDs := lo.Keyify(oldItem.StatsOptionIDs())
+			for _, optID := range newItem.StatsOptionIDs() {
+				if _, ok := oldOptionIDs[optID]; !ok {
+					newStatsList = append(newStatsList, &model.Stats{
+						SurveyID:   oldSurvey.ID,
+						QuestionID: newItem.ID,
+						OptionID:   optID,
+					})
 				}
 			}
-		} else if newItem.IsOptionType() {
-			// 新增选项类题型
-			for _, newOpt := range newItem.Options {
+		} else {
+			// 新增选项类及级联题型
+			for _, optID := range newItem.StatsOptionIDs() {
 				newStatsList = append(newStatsList, &model.Stats{
 					SurveyID:   oldSurvey.ID,
 					QuestionID: newItem.ID,
-					OptionID:   newOpt.ID,
+					OptionID:   optID,
 				})
 			}
 		}
@@ -128,7 +132,7 @@ func (u *UpdateApi) Run(ctx *gin.Context) kit.Code {
 		return comm.CodeDataParseError
 	}
 
-	// 事务 更新问卷 -> 创建新增统计数据
+	// 事务 更新问卷 -> 创建新增统计数据 -> 记录审计日志
 	err = repo.Transaction(func(tx *query.Query) error {
 		// 更新问卷
 		if _, err := repo.NewSurveyRepo(tx).UpdateSchema(ctx, oldSurvey.ID, req.Schema.BannerConf.TitleConf.MainTitle, schemaStr); err != nil {
@@ -142,7 +146,19 @@ func (u *UpdateApi) Run(ctx *gin.Context) kit.Code {
 			}
 		}
 
-		return nil
+		// 记录审计日志
+		return repo.NewAuditLogRepo(tx).Record(ctx, repo.AuditEntry{
+			AdminID:    admin.ID,
+			Action:     comm.AuditActionSurveyUpdate,
+			TargetType: comm.AuditTargetSurvey,
+			TargetID:   oldSurvey.ID,
+			Detail: map[string]any{
+				"title": req.Schema.BannerConf.TitleConf.MainTitle,
+				"diff":  schema.Diff(&oldSchema, &req.Schema),
+			},
+			IP:        ctx.ClientIP(),
+			RequestID: requestid.Get(ctx),
+		})
 	})
 	if err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("更新问卷失败")
@@ -154,6 +170,11 @@ func (u *UpdateApi) Run(ctx *gin.Context) kit.Code {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("删除问卷缓存失败")
 	}
 
+	// 标记读主库 避免随后的列表查询读到从库未同步的数据
+	if err := cache.NewPrimaryPinCache().Pin(ctx, admin.ID); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("设置读主库标记失败")
+	}
+
 	return comm.CodeOK
 }
 
@@ -0,0 +1,113 @@
+package system
+
+import (
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/repo"
+)
+
+// MaintenanceHandler API router注册点
+func MaintenanceHandler() gin.HandlerFunc {
+	api := MaintenanceApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfMaintenance).Pointer()).Name()] = api
+	return hfMaintenance
+}
+
+type MaintenanceApi struct {
+	Info     struct{}               `name:"设置维护模式" desc:"开启后拒绝提交问卷及管理端写操作 查询接口及登录不受影响 仅超级管理员可用"`
+	Request  MaintenanceApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response MaintenanceApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type MaintenanceApiRequest struct {
+	Body struct {
+		Enabled bool `json:"enabled" desc:"是否开启维护模式"`
+	}
+}
+
+type MaintenanceApiResponse struct{}
+
+// Run Api业务逻辑执行点
+func (m *MaintenanceApi) Run(ctx *gin.Context) kit.Code {
+	req := m.Request.Body
+
+	// 获取登录管理员信息
+	admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	// 校验权限
+	if admin.Type != comm.AdminTypeSuper {
+		return comm.CodePermissionDenied
+	}
+
+	// 设置维护模式
+	maintenance := cache.NewMaintenanceCache()
+	if req.Enabled {
+		err = maintenance.Enable(ctx, time.Now())
+	} else {
+		err = maintenance.Disable(ctx)
+	}
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("设置维护模式失败")
+		return comm.CodeRedisError
+	}
+
+	// 记录审计日志 维护期间数据库可能不可用 记录失败不影响维护模式切换
+	err = repo.NewAuditLogRepo().Record(ctx, repo.AuditEntry{
+		AdminID:    admin.ID,
+		Action:     comm.AuditActionMaintenance,
+		TargetType: comm.AuditTargetSystem,
+		Detail: map[string]any{
+			"enabled": req.Enabled,
+		},
+		IP:        ctx.ClientIP(),
+		RequestID: requestid.Get(ctx),
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("记录审计日志失败")
+	}
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (m *MaintenanceApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&m.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfMaintenance API执行入口
+func hfMaintenance(ctx *gin.Context) {
+	api := &MaintenanceApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
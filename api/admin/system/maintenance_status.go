@@ -0,0 +1,73 @@
+package system
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+)
+
+// MaintenanceStatusHandler API router注册点
+func MaintenanceStatusHandler() gin.HandlerFunc {
+	api := MaintenanceStatusApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfMaintenanceStatus).Pointer()).Name()] = api
+	return hfMaintenanceStatus
+}
+
+type MaintenanceStatusApi struct {
+	Info     struct{}                     `name:"获取维护模式状态" desc:"获取全局维护模式是否开启"`
+	Request  MaintenanceStatusApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response MaintenanceStatusApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type MaintenanceStatusApiRequest struct{}
+
+type MaintenanceStatusApiResponse struct {
+	Enabled bool  `json:"enabled" desc:"是否开启维护模式"`
+	Since   int64 `json:"since,omitempty" desc:"开启时间(Unix毫秒) enabled=true时返回"`
+}
+
+// Run Api业务逻辑执行点
+func (m *MaintenanceStatusApi) Run(ctx *gin.Context) kit.Code {
+	since, err := cache.NewMaintenanceCache().Get(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询维护模式失败")
+		return comm.CodeRedisError
+	}
+	if since != nil {
+		m.Response.Enabled = true
+		m.Response.Since = since.UnixMilli()
+	}
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (m *MaintenanceStatusApi) Init(ctx *gin.Context) (err error) {
+	return err
+}
+
+// hfMaintenanceStatus API执行入口
+func hfMaintenanceStatus(ctx *gin.Context) {
+	api := &MaintenanceStatusApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
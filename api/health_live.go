@@ -0,0 +1,65 @@
+package api
+
+import (
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+)
+
+// HealthLiveHandler Api router注册点
+func HealthLiveHandler() gin.HandlerFunc {
+	api := HealthLiveApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfHealthLive).Pointer()).Name()] = api
+	return hfHealthLive
+}
+
+type HealthLiveApi struct {
+	Info     struct{} `name:"存活检查接口" desc:"进程存活即返回成功 不检查外部依赖 用于Kubernetes livenessProbe"`
+	Request  HealthLiveApiRequest
+	Response HealthLiveApiResponse
+}
+
+type HealthLiveApiRequest struct {
+}
+
+type HealthLiveApiResponse struct {
+	Now int64 `json:"now" desc:"当前服务器时间戳(Unix秒级)"`
+}
+
+// Run Api业务逻辑执行点
+func (h *HealthLiveApi) Run(ctx *gin.Context) kit.Code {
+	h.Response.Now = time.Now().Unix()
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (h *HealthLiveApi) Init(ctx *gin.Context) (err error) {
+	return err
+}
+
+// hfHealthLive Api执行入口
+func hfHealthLive(ctx *gin.Context) {
+	api := &HealthLiveApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
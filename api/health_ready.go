@@ -0,0 +1,169 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/ndb"
+	"github.com/zjutjh/mygo/nedis"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+)
+
+const healthCheckTimeout = 2 * time.Second // 单个依赖检查超时时长
+
+const (
+	healthStatusUp      = "up"
+	healthStatusDown    = "down"
+	healthStatusTimeout = "timeout"
+)
+
+// healthDependency 就绪检查依赖项 critical=true的依赖不可用时实例不接收流量
+type healthDependency struct {
+	name     string
+	critical bool
+	ping     func(ctx context.Context) error
+}
+
+// healthDependencies 就绪检查依赖列表 新增存储等外部依赖时在此注册
+func healthDependencies() []healthDependency {
+	deps := []healthDependency{
+		{name: "mysql", critical: true, ping: func(ctx context.Context) error {
+			db, err := ndb.Pick().DB()
+			if err != nil {
+				return err
+			}
+			return db.PingContext(ctx)
+		}},
+		{name: "redis", critical: true, ping: func(ctx context.Context) error {
+			return nedis.Pick().Ping(ctx).Err()
+		}},
+	}
+	// 从库不可用时仅影响管理端统计查询 不影响接收流量
+	for _, scope := range comm.BizConf.DBReplica.Scopes {
+		deps = append(deps, healthDependency{name: scope, critical: false, ping: func(ctx context.Context) error {
+			db, err := ndb.Pick(scope).DB()
+			if err != nil {
+				return err
+			}
+			return db.PingContext(ctx)
+		}})
+	}
+	return deps
+}
+
+// HealthReadyHandler Api router注册点
+func HealthReadyHandler() gin.HandlerFunc {
+	api := HealthReadyApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfHealthReady).Pointer()).Name()] = api
+	return hfHealthReady
+}
+
+type HealthReadyApi struct {
+	Info     struct{} `name:"就绪检查接口" desc:"检查数据库/Redis等依赖 关键依赖不可用时返回HTTP 503 用于Kubernetes readinessProbe"`
+	Request  HealthReadyApiRequest
+	Response HealthReadyApiResponse
+}
+
+type HealthReadyApiRequest struct {
+}
+
+type HealthReadyApiResponse struct {
+	Status       string                   `json:"status" desc:"整体状态 up:可接收流量 down:关键依赖不可用"`
+	Dependencies []HealthDependencyStatus `json:"dependencies" desc:"各依赖检查结果"`
+}
+
+type HealthDependencyStatus struct {
+	Name      string `json:"name" desc:"依赖名称"`
+	Critical  bool   `json:"critical" desc:"是否为关键依赖"`
+	Status    string `json:"status" desc:"检查结果 up:正常 down:异常 timeout:超时"`
+	LatencyMs int64  `json:"latency_ms" desc:"检查耗时 单位毫秒"`
+}
+
+// Run Api业务逻辑执行点
+func (h *HealthReadyApi) Run(ctx *gin.Context) kit.Code {
+	deps := healthDependencies()
+	h.Response.Dependencies = make([]HealthDependencyStatus, len(deps))
+
+	// 并发检查 总耗时不超过单个依赖的超时时长
+	var wg sync.WaitGroup
+	for i, dep := range deps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.Response.Dependencies[i] = checkHealthDependency(ctx, dep)
+		}()
+	}
+	wg.Wait()
+
+	h.Response.Status = healthStatusUp
+	for _, dep := range h.Response.Dependencies {
+		if dep.Critical && dep.Status != healthStatusUp {
+			h.Response.Status = healthStatusDown
+			return comm.CodeMiddlewareServiceError
+		}
+	}
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (h *HealthReadyApi) Init(ctx *gin.Context) (err error) {
+	return err
+}
+
+// hfHealthReady Api执行入口
+func hfHealthReady(ctx *gin.Context) {
+	api := &HealthReadyApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			// 探针仅依据HTTP状态码判断 未就绪时返回503 并附带各依赖检查结果
+			ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, reply.Response{
+				Code:    code.Code,
+				Message: code.Message,
+				Data:    api.Response,
+			})
+		}
+	}
+}
+
+// checkHealthDependency 检查单个依赖 错误详情仅记录日志 不在响应中返回
+func checkHealthDependency(ctx *gin.Context, dep healthDependency) HealthDependencyStatus {
+	pingCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := dep.ping(pingCtx)
+	status := HealthDependencyStatus{
+		Name:      dep.name,
+		Critical:  dep.critical,
+		Status:    healthStatusUp,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		status.Status = healthStatusDown
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(pingCtx.Err(), context.DeadlineExceeded) {
+			status.Status = healthStatusTimeout
+		}
+		nlog.Pick().WithContext(ctx).WithError(err).Warnf("就绪检查依赖不可用 依赖:%s", dep.name)
+	}
+	return status
+}
@@ -6,12 +6,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/zjutjh/mygo/foundation/reply"
-	"github.com/zjutjh/mygo/jwt"
 	"github.com/zjutjh/mygo/kit"
 	"github.com/zjutjh/mygo/nlog"
 	"github.com/zjutjh/mygo/swagger"
 
 	"app/comm"
+	"app/dao/cache"
 )
 
 // LoginHandler API router注册点
@@ -35,25 +35,40 @@ type LoginApiRequest struct {
 }
 
 type LoginApiResponse struct {
-	Token string `json:"token" desc:"token"`
+	Token        string `json:"token" desc:"访问Token"`
+	RefreshToken string `json:"refresh_token" desc:"刷新Token 访问Token过期后用于续期 仅可使用一次"`
 }
 
 // Run Api业务逻辑执行点
 func (l *LoginApi) Run(ctx *gin.Context) kit.Code {
 	req := l.Request.Body
 
-	// TODO: 接入用户中心
+	// 检查登录尝试限制
+	guard := cache.NewLoginGuardCache(cache.LoginGuardScopeUser)
+	wait, err := guard.Wait(ctx, req.Username, ctx.ClientIP())
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询登录尝试限制失败")
+		return comm.CodeRedisError
+	}
+	if wait > 0 {
+		return comm.CodeLoginLimited
+	}
 
-	// 生成 Token
-	token, err := jwt.Pick[comm.UserIdentity]("jwt_user").GenerateToken(comm.UserIdentity{
+	// TODO: 接入用户中心 校验失败时调用guard.Fail记录失败次数并返回comm.CodeInvalidCredentials
+	if err := guard.Reset(ctx, req.Username); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("清除登录失败次数失败")
+	}
+
+	// 签发 Token
+	token, refreshToken, code := issueToken(ctx, comm.UserIdentity{
 		Username: req.Username,
 		Type:     comm.UserTypeUndergrad,
 	})
-	if err != nil {
-		nlog.Pick().WithContext(ctx).WithError(err).Error("生成 Token 失败")
-		return comm.CodeUnknownError
+	if code != comm.CodeOK {
+		return code
 	}
 	l.Response.Token = token
+	l.Response.RefreshToken = refreshToken
 
 	return comm.CodeOK
 }
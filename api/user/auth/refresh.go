@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+)
+
+// RefreshHandler API router注册点
+func RefreshHandler() gin.HandlerFunc {
+	api := RefreshApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfRefresh).Pointer()).Name()] = api
+	return hfRefresh
+}
+
+type RefreshApi struct {
+	Info     struct{}           `name:"刷新用户Token" desc:"使用刷新Token换取新的访问Token及刷新Token 原刷新Token立即失效"`
+	Request  RefreshApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response RefreshApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type RefreshApiRequest struct {
+	Body struct {
+		RefreshToken string `json:"refresh_token" binding:"required,max=64" desc:"刷新Token"`
+	}
+}
+
+type RefreshApiResponse struct {
+	Token        string `json:"token" desc:"访问Token"`
+	RefreshToken string `json:"refresh_token" desc:"刷新Token 访问Token过期后用于续期 仅可使用一次"`
+}
+
+// Run Api业务逻辑执行点
+func (r *RefreshApi) Run(ctx *gin.Context) kit.Code {
+	req := r.Request.Body
+	sessionCache := cache.NewSessionCache(cache.SessionScopeUser)
+
+	// 取出刷新Token会话记录
+	session, err := sessionCache.TakeRefresh(ctx, req.RefreshToken)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询刷新 Token 失败")
+		return comm.CodeRedisError
+	}
+	if session == nil {
+		return comm.CodeLoginExpired
+	}
+
+	// 校验会话是否已被吊销
+	active, err := sessionCache.Active(ctx, session.Subject, "", session.IssuedAt)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询会话吊销状态失败")
+		return comm.CodeRedisError
+	}
+	if !active {
+		return comm.CodeLoginExpired
+	}
+
+	var identity comm.UserIdentity
+	if err := sonic.UnmarshalString(session.Identity, &identity); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("登录身份反序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 签发 Token
+	token, refreshToken, code := issueToken(ctx, identity)
+	if code != comm.CodeOK {
+		return code
+	}
+	r.Response.Token = token
+	r.Response.RefreshToken = refreshToken
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (r *RefreshApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&r.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfRefresh API执行入口
+func hfRefresh(ctx *gin.Context) {
+	api := &RefreshApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
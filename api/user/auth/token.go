@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"crypto/rand"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/comm"
+	"app/dao/cache"
+)
+
+// issueToken 签发访问Token及刷新Token
+func issueToken(ctx *gin.Context, identity comm.UserIdentity) (accessToken, refreshToken string, code kit.Code) {
+	now := time.Now().UnixMilli()
+
+	// 生成访问 Token
+	identity.TokenSession = comm.TokenSession{
+		TokenID:  uuid.NewString(),
+		IssuedAt: now,
+	}
+	accessToken, err := jwt.Pick[comm.UserIdentity]("jwt_user").GenerateToken(identity)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("生成 Token 失败")
+		return "", "", comm.CodeUnknownError
+	}
+
+	// 生成刷新 Token
+	identity.TokenSession = comm.TokenSession{}
+	identityJSON, err := sonic.MarshalString(identity)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("登录身份序列化失败")
+		return "", "", comm.CodeUnknownError
+	}
+	refreshToken = rand.Text()
+	err = cache.NewSessionCache(cache.SessionScopeUser).SetRefresh(ctx, refreshToken, cache.RefreshSession{
+		Subject:  identity.Subject(),
+		Identity: identityJSON,
+		IssuedAt: now,
+	})
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("保存刷新 Token 失败")
+		return "", "", comm.CodeRedisError
+	}
+
+	return accessToken, refreshToken, comm.CodeOK
+}
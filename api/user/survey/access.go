@@ -0,0 +1,102 @@
+package survey
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// errAccessCodeUsed 一次性访问码已被并发请求使用 用于回滚提交事务
+var errAccessCodeUsed = errors.New("access code already used")
+
+// checkAccess 校验问卷访问限制 一次性访问码此处仅校验可用性 提交时在事务中使用
+// 按问卷及IP限制连续失败次数 防止猜测访问密码
+func checkAccess(ctx *gin.Context, surveyID int64, conf *schema.BaseConf, accessCode string) kit.Code {
+	if conf.AccessMode == "" {
+		return comm.CodeOK
+	}
+	if accessCode == "" {
+		return comm.CodeSurveyAccessNeeded
+	}
+
+	// 检查访问尝试限制
+	guard := cache.NewLoginGuardCache(cache.LoginGuardScopeSurvey)
+	subject := strconv.FormatInt(surveyID, 10) + ":" + ctx.ClientIP()
+	wait, err := guard.Wait(ctx, subject, ctx.ClientIP())
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询访问尝试限制失败")
+		return comm.CodeRedisError
+	}
+	if wait > 0 {
+		return comm.CodeSurveyAccessLimit
+	}
+
+	code := verifyAccess(ctx, surveyID, conf, accessCode)
+	switch code {
+	case comm.CodeOK:
+		if err := guard.Reset(ctx, subject); err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("清除访问失败次数失败")
+		}
+	case comm.CodeSurveyAccessDenied:
+		if err := guard.Fail(ctx, subject, ctx.ClientIP()); err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("记录访问失败次数失败")
+		}
+	}
+	return code
+}
+
+// verifyAccess 校验访问密码或一次性访问码
+func verifyAccess(ctx *gin.Context, surveyID int64, conf *schema.BaseConf, accessCode string) kit.Code {
+	switch conf.AccessMode {
+	case comm.AccessModePassword:
+		if subtle.ConstantTimeCompare([]byte(accessCode), []byte(conf.AccessPassword)) != 1 {
+			return comm.CodeSurveyAccessDenied
+		}
+	case comm.AccessModeCode:
+		record, err := repo.NewSurveyAccessCodeRepo().FindByCode(ctx, surveyID, comm.NormalizeAccessCode(accessCode))
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷访问码失败")
+			return comm.CodeDatabaseError
+		}
+		if record == nil || record.UsedAt > 0 {
+			return comm.CodeSurveyAccessDenied
+		}
+	default:
+		return comm.CodeSurveyAccessDenied
+	}
+
+	return comm.CodeOK
+}
+
+// checkAllowlist 校验登录用户是否在问卷答题名单内 问卷未启用答题名单时直接通过
+func checkAllowlist(ctx *gin.Context, surveyID int64, conf *schema.BaseConf) kit.Code {
+	if !conf.IsLoginRequired || !conf.UseAllowlist {
+		return comm.CodeOK
+	}
+
+	user, err := jwt.GetIdentity[comm.UserIdentity](ctx)
+	if err != nil {
+		return comm.CodeNotLoggedIn
+	}
+
+	allowed, err := repo.NewSurveyAllowlistRepo().Exists(ctx, surveyID, user.Username)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷答题名单失败")
+		return comm.CodeDatabaseError
+	}
+	if !allowed {
+		return comm.CodeSurveyNotAllowed
+	}
+
+	return comm.CodeOK
+}
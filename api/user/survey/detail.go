@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"runtime"
 	"sort"
+	"strconv"
 
 	"github.com/bytedance/sonic"
 	"github.com/gin-gonic/gin"
@@ -15,6 +16,7 @@ import (
 	"github.com/zjutjh/mygo/nlog"
 	"github.com/zjutjh/mygo/swagger"
 	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
 
 	"app/comm"
 	"app/dao/cache"
@@ -40,15 +42,19 @@ type DetailApi struct {
 
 type DetailApiRequest struct {
 	Query struct {
-		Path string `form:"path" binding:"required,max=64" desc:"访问路径"`
+		Path       string `form:"path" binding:"required,max=64" desc:"访问路径"`
+		Lang       string `form:"lang" binding:"omitempty,max=35" desc:"语言 为空时按Accept-Language匹配"`
+		AccessCode string `form:"access_code" binding:"omitempty,max=32" desc:"访问密码或一次性访问码 问卷设置访问限制时必填"`
 	}
 }
 
 type DetailApiResponse struct {
 	ID     int64               `json:"id" desc:"问卷ID"`
 	Type   comm.SurveyType     `json:"type" desc:"问卷类型"`
+	Locale string              `json:"locale" desc:"问卷展示语言 未配置多语言时为空"`
 	Schema schema.SurveySchema `json:"schema" desc:"问卷结构"`
 	Stats  []StatsItem         `json:"stats" desc:"选项统计数据"`
+	Frozen bool                `json:"frozen" desc:"是否暂停提交 暂停期间可查看但不可提交"`
 }
 
 type StatsItem struct {
@@ -66,22 +72,37 @@ type Option struct {
 func (d *DetailApi) Run(ctx *gin.Context) kit.Code {
 	req := d.Request.Query
 
+	// 访问路径过滤器判定不存在时直接拒绝
+	if !cache.SurveyPathMayExist(req.Path) {
+		return comm.CodeDataNotFound
+	}
+
 	// 查询问卷缓存
 	survey, err := cache.NewSurveyCache().Get(ctx, req.Path)
+	if errors.Is(err, kit.ErrNotFound) {
+		return comm.CodeDataNotFound
+	}
 	if err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷缓存失败")
 	}
 	// 缓存未命中 回源数据库
 	if survey == nil {
 		// singleflight 防止缓存穿透
-		val, err, _ := sf.Do(req.Path, func() (any, error) {
+		val, err, shared := sf.Do(req.Path, func() (any, error) {
 			// 数据库查询问卷
 			record, err := repo.NewSurveyRepo().FindByPath(ctx, req.Path)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				record, err = nil, nil
+			}
 			if err != nil {
 				nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
 				return nil, err
 			}
 			if record == nil {
+				// 缓存不存在的访问路径 防止缓存穿透
+				if err := cache.NewSurveyCache().SetMissing(ctx, req.Path); err != nil {
+					nlog.Pick().WithContext(ctx).WithError(err).Error("设置问卷缓存失败")
+				}
 				return nil, kit.ErrNotFound
 			}
 
@@ -92,11 +113,12 @@ func (d *DetailApi) Run(ctx *gin.Context) kit.Code {
 
 			return record, nil
 		})
+		comm.MetricSingleflightTotal.WithLabelValues(strconv.FormatBool(shared)).Inc()
 		if err != nil {
-			nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
 			if errors.Is(err, kit.ErrNotFound) {
 				return comm.CodeDataNotFound
 			}
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
 			return comm.CodeDatabaseError
 		}
 
@@ -116,6 +138,23 @@ func (d *DetailApi) Run(ctx *gin.Context) kit.Code {
 		return comm.CodeDataParseError
 	}
 
+	// 检查访问限制 未通过时不返回问卷结构
+	if code := checkAccess(ctx, survey.ID, &surveySchema.BaseConf, req.AccessCode); code != comm.CodeOK {
+		return code
+	}
+
+	// 检查答题名单
+	if code := checkAllowlist(ctx, survey.ID, &surveySchema.BaseConf); code != comm.CodeOK {
+		return code
+	}
+
+	// 清理不可公开的配置
+	surveySchema.Desensitize()
+
+	// 按请求语言切换问卷文本
+	locale := surveySchema.MatchLocale(req.Lang, ctx.GetHeader("Accept-Language"))
+	surveySchema.Localize(locale)
+
 	// 筛选需要显示统计数据的投票类题目
 	voteQuestions := lo.Filter(surveySchema.QuestionConf.Items, func(item schema.QuestionItem, _ int) bool {
 		return item.IsVoteType() && item.ShowStats
@@ -132,7 +171,7 @@ func (d *DetailApi) Run(ctx *gin.Context) kit.Code {
 		if needSubmit {
 			user, err := jwt.GetIdentity[comm.UserIdentity](ctx)
 			if err == nil {
-				count, err := repo.NewResultRepo().CountByUser(ctx, survey.ID, user.Username, nil)
+				count, err := repo.NewResultRepo().ForSurvey(survey).CountByUser(ctx, survey.ID, user.Username, nil)
 				if err == nil && count > 0 {
 					hasSubmitted = true
 				}
@@ -207,8 +246,10 @@ func (d *DetailApi) Run(ctx *gin.Context) kit.Code {
 	d.Response = DetailApiResponse{
 		ID:     survey.ID,
 		Type:   comm.SurveyType(survey.Type),
+		Locale: locale,
 		Schema: surveySchema,
 		Stats:  stats,
+		Frozen: survey.FrozenAt > 0,
 	}
 
 	return comm.CodeOK
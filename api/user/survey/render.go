@@ -0,0 +1,145 @@
+package survey
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// RenderHandler API router注册点
+func RenderHandler() gin.HandlerFunc {
+	api := RenderApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfRender).Pointer()).Name()] = api
+	return hfRender
+}
+
+type RenderApi struct {
+	Info     struct{}          `name:"渲染答案引用" desc:"按当前已填答案渲染题目标题、描述及选项文本中的答案引用 仅返回包含引用的题目"`
+	Request  RenderApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response RenderApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type RenderApiRequest struct {
+	Body struct {
+		ID         int64             `json:"id" binding:"required,gte=1" desc:"问卷ID"`
+		Result     []comm.ResultItem `json:"result" desc:"当前已填答案"`
+		Lang       string            `json:"lang" binding:"omitempty,max=35" desc:"语言 为空时按Accept-Language匹配"`
+		AccessCode string            `json:"access_code" binding:"omitempty,max=32" desc:"访问密码或一次性访问码 问卷设置访问限制时必填"`
+	}
+}
+
+type RenderApiResponse struct {
+	List []RenderItem `json:"list" desc:"渲染后的题目列表"`
+}
+
+type RenderItem struct {
+	ID      string         `json:"id" desc:"题目ID"`
+	Title   string         `json:"title" desc:"题目标题"`
+	Desc    string         `json:"desc" desc:"题目描述"`
+	Options []RenderOption `json:"options" desc:"选项列表"`
+}
+
+type RenderOption struct {
+	ID   string `json:"id" desc:"选项ID"`
+	Text string `json:"text" desc:"选项文本"`
+}
+
+// Run Api业务逻辑执行点
+func (r *RenderApi) Run(ctx *gin.Context) kit.Code {
+	req := r.Request.Body
+
+	// 查询问卷
+	survey, err := repo.NewSurveyRepo().FindByID(ctx, req.ID)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
+		return comm.CodeDatabaseError
+	}
+	if survey == nil || comm.SurveyStatus(survey.Status) != comm.SurveyStatusPublished {
+		return comm.CodeDataNotFound
+	}
+
+	// 问卷结构反序列化
+	var surveySchema schema.SurveySchema
+	if err := sonic.UnmarshalString(survey.Schema, &surveySchema); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("问卷结构反序列化失败")
+		return comm.CodeDataParseError
+	}
+
+	// 检查访问限制
+	if code := checkAccess(ctx, survey.ID, &surveySchema.BaseConf, req.AccessCode); code != comm.CodeOK {
+		return code
+	}
+
+	// 检查答题名单
+	if code := checkAllowlist(ctx, survey.ID, &surveySchema.BaseConf); code != comm.CodeOK {
+		return code
+	}
+
+	// 按请求语言切换问卷文本
+	surveySchema.Localize(surveySchema.MatchLocale(req.Lang, ctx.GetHeader("Accept-Language")))
+
+	// 渲染答案引用
+	pipeIDs := lo.FilterMap(surveySchema.QuestionConf.Items, func(item schema.QuestionItem, _ int) (string, bool) {
+		return item.ID, item.HasPipe()
+	})
+	answerMap := lo.SliceToMap(req.Result, func(item comm.ResultItem) (string, string) {
+		return item.QuestionID, item.Answer
+	})
+	surveySchema.Render(answerMap)
+
+	// 构建响应数据
+	r.Response.List = lo.FilterMap(surveySchema.QuestionConf.Items, func(item schema.QuestionItem, _ int) (RenderItem, bool) {
+		return RenderItem{
+			ID:    item.ID,
+			Title: item.Title,
+			Desc:  item.Desc,
+			Options: lo.Map(item.Options, func(opt schema.Option, _ int) RenderOption {
+				return RenderOption{
+					ID:   opt.ID,
+					Text: opt.Text,
+				}
+			}),
+		}, lo.Contains(pipeIDs, item.ID)
+	})
+
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (r *RenderApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindJSON(&r.Request.Body)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfRender API执行入口
+func hfRender(ctx *gin.Context) {
+	api := &RenderApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
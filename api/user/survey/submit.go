@@ -1,9 +1,8 @@
 package survey
 
 import (
-	"path/filepath"
+	"errors"
 	"reflect"
-	"regexp"
 	"runtime"
 	"slices"
 	"strings"
@@ -12,26 +11,21 @@ import (
 	"github.com/bytedance/sonic"
 	"github.com/gin-gonic/gin"
 	"github.com/samber/lo"
-	"github.com/shopspring/decimal"
 	"github.com/zjutjh/mygo/foundation/reply"
 	"github.com/zjutjh/mygo/jwt"
 	"github.com/zjutjh/mygo/kit"
 	"github.com/zjutjh/mygo/nlog"
 	"github.com/zjutjh/mygo/swagger"
+	"gorm.io/gorm"
 
 	"app/comm"
+	"app/dao/cache"
 	"app/dao/model"
 	"app/dao/query"
 	"app/dao/repo"
 	"app/schema"
 )
 
-var (
-	regexMobile = regexp.MustCompile(`^1[3-9]\d{9}$`)
-	regexEmail  = regexp.MustCompile(`^\w+([-+.]\w+)*@\w+([-.]\w+)*\.\w+([-.]\w+)*$`)
-	regexIDCard = regexp.MustCompile(`(^\d{15}$)|(^\d{18}$)|(^\d{17}(\d|X|x)$)`)
-)
-
 // SubmitHandler API router注册点
 func SubmitHandler() gin.HandlerFunc {
 	api := SubmitApi{}
@@ -47,12 +41,30 @@ type SubmitApi struct {
 
 type SubmitApiRequest struct {
 	Body struct {
-		ID     int64             `json:"id" binding:"required,gte=1" desc:"问卷ID"`
-		Result []comm.ResultItem `json:"result" binding:"required,min=1" desc:"答卷结果"`
+		ID         int64             `json:"id" binding:"required,gte=1" desc:"问卷ID"`
+		Result     []comm.ResultItem `json:"result" binding:"required,min=1" desc:"答卷结果"`
+		AccessCode string            `json:"access_code" binding:"omitempty,max=32" desc:"访问密码或一次性访问码 问卷设置访问限制时必填 一次性访问码提交成功后失效"`
 	}
 }
 
-type SubmitApiResponse struct{}
+type SubmitApiResponse struct {
+	Quiz   *QuizResult `json:"quiz" desc:"测验结果 仅测验类问卷开启结果展示时返回"`
+	Ticket string      `json:"ticket,omitempty" desc:"提交凭证 仅问卷开启异步提交时返回 可通过提交状态接口查询入库结果"`
+}
+
+type QuizResult struct {
+	Score     *int         `json:"score,omitempty" desc:"得分 show_score=true时返回"`
+	FullScore *int         `json:"full_score,omitempty" desc:"总分 show_score=true时返回"`
+	Answers   []QuizAnswer `json:"answers,omitempty" desc:"各题判分及正确答案 show_answer=true时返回"`
+}
+
+type QuizAnswer struct {
+	ID        string   `json:"id" desc:"题目ID"`
+	Correct   bool     `json:"correct" desc:"是否正确"`
+	Score     int      `json:"score" desc:"得分"`
+	FullScore int      `json:"full_score" desc:"满分"`
+	Answers   []string `json:"answers" desc:"正确答案 选项类题型为选项ID列表"`
+}
 
 // Run Api业务逻辑执行点
 func (s *SubmitApi) Run(ctx *gin.Context) kit.Code {
@@ -60,6 +72,9 @@ func (s *SubmitApi) Run(ctx *gin.Context) kit.Code {
 
 	// 查询问卷
 	survey, err := repo.NewSurveyRepo().FindByID(ctx, req.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return comm.CodeDataNotFound
+	}
 	if err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷失败")
 		return comm.CodeDatabaseError
@@ -73,21 +88,30 @@ func (s *SubmitApi) Run(ctx *gin.Context) kit.Code {
 		return comm.CodeDataNotFound
 	}
 
-	// 问卷结构反序列化
-	var surveySchema schema.SurveySchema
-	if err := sonic.UnmarshalString(survey.Schema, &surveySchema); err != nil {
+	// 检查问卷是否暂停提交
+	if survey.FrozenAt > 0 {
+		return comm.CodeSurveySubmitFrozen
+	}
+
+	// 获取预编译的问卷结构
+	compiled, err := cache.GetCompiledSchema(survey)
+	if err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("问卷结构反序列化失败")
 		return comm.CodeDataParseError
 	}
+	surveySchema := &compiled.Schema
 
-	// 检查问卷时间有效期
+	// 检查问卷时间有效期 答卷已归档或归档中的问卷须恢复后才可继续提交
 	now := time.Now()
-	beginTime, _ := time.ParseInLocation(time.DateTime, surveySchema.BaseConf.BeginTime, time.Local)
-	endTime, _ := time.ParseInLocation(time.DateTime, surveySchema.BaseConf.EndTime, time.Local)
-	if now.Before(beginTime) || now.After(endTime) {
+	if now.Before(compiled.BeginTime) || now.After(compiled.EndTime) || survey.ArchivedAt > 0 || survey.ArchivingAt > 0 {
 		return comm.CodeSurveyTimeInvalid
 	}
 
+	// 检查访问限制
+	if code := checkAccess(ctx, survey.ID, &surveySchema.BaseConf, req.AccessCode); code != comm.CodeOK {
+		return code
+	}
+
 	// 检查登录及提交限制
 	var username string
 	if surveySchema.BaseConf.IsLoginRequired {
@@ -105,32 +129,15 @@ func (s *SubmitApi) Run(ctx *gin.Context) kit.Code {
 			}
 		}
 
-		// 检查总提交限制
-		if surveySchema.BaseConf.TotalLimit > 0 {
-			count, err := repo.NewResultRepo().CountByUser(ctx, survey.ID, username, nil)
-			if err != nil {
-				nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷总提交次数失败")
-				return comm.CodeDatabaseError
-			}
-			if count >= surveySchema.BaseConf.TotalLimit {
-				return comm.CodeSurveySubmitLimit
-			}
+		// 检查答题名单
+		if code := checkAllowlist(ctx, survey.ID, &surveySchema.BaseConf); code != comm.CodeOK {
+			return code
 		}
 
-		// 检查每日提交限制
-		if surveySchema.BaseConf.DailyLimit > 0 {
-			start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
-			end := start.Add(24 * time.Hour)
-			count, err := repo.NewResultRepo().CountByUser(ctx, survey.ID, username, &repo.TimeRange{
-				Start: start,
-				End:   end,
-			})
-			if err != nil {
-				nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷今日提交次数失败")
-				return comm.CodeDatabaseError
-			}
-			if count >= surveySchema.BaseConf.DailyLimit {
-				return comm.CodeSurveySubmitLimit
+		// 检查提交限制 异步提交时预占提交次数后检查 不在此处重复查询
+		if !surveySchema.BaseConf.AsyncSubmit {
+			if code := checkSubmitLimit(ctx, &surveySchema.BaseConf, survey.ID, username, now); code != comm.CodeOK {
+				return code
 			}
 		}
 	}
@@ -139,137 +146,54 @@ func (s *SubmitApi) Run(ctx *gin.Context) kit.Code {
 	answerMap := lo.SliceToMap(req.Result, func(item comm.ResultItem) (string, string) {
 		return item.QuestionID, item.Answer
 	})
-	statsUpdates := make([]repo.StatsUpdate, 0)
-	for _, item := range surveySchema.QuestionConf.Items {
-		val, exists := answerMap[item.ID]
-
-		// 检查必填
-		if item.IsRequired && (!exists || val == "") {
-			nlog.Pick().WithContext(ctx).Warnf("必填项未填 ID:%s", item.ID)
-			return comm.CodeParameterInvalid
-		}
-
-		if !exists || val == "" {
-			continue
+	statsRefs, err := compiled.Validate(answerMap)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("答卷结果校验失败")
+		return comm.CodeParameterInvalid
+	}
+	statsUpdates := lo.Map(statsRefs, func(ref schema.StatsRef, _ int) repo.StatsUpdate {
+		return repo.StatsUpdate{
+			QuestionID: ref.QuestionID,
+			OptionID:   ref.OptionID,
 		}
+	})
 
-		// 选项类题型
-		if item.IsOptionType() {
-			selectedOpts := strings.Split(val, ",")
-
-			// 多选题校验选项数量
-			if item.IsCheckboxType() {
-				if (item.MinNum > 0 && len(selectedOpts) < item.MinNum) ||
-					(item.MaxNum > 0 && len(selectedOpts) > item.MaxNum) {
-					nlog.Pick().WithContext(ctx).Warnf("选项数量不符合要求 ID:%s", item.ID)
-					return comm.CodeParameterInvalid
-				}
-			}
-
-			// 校验选项是否存在
-			optMap := lo.KeyBy(item.Options, func(o schema.Option) string {
-				return o.ID
-			})
-			for _, optID := range selectedOpts {
-				opt, ok := optMap[optID]
-				if !ok {
-					nlog.Pick().WithContext(ctx).Warnf("选项不存在 ID:%s OptID:%s", item.ID, optID)
-					return comm.CodeParameterInvalid
-				}
-
-				// 收集统计数据
-				statsUpdates = append(statsUpdates, repo.StatsUpdate{
-					QuestionID: item.ID,
-					OptionID:   optID,
-				})
-
-				// 检查自定义输入内容选项
-				if opt.Others {
-					othersVal, ok := answerMap[opt.OthersKey]
-					if opt.MustOthers && (!ok || othersVal == "") {
-						nlog.Pick().WithContext(ctx).Warnf("自定义输入内容选项必填未填 ID:%s OptID:%s", item.ID, optID)
-						return comm.CodeParameterInvalid
-					}
-				}
-			}
-		} else if item.IsInputType() {
-			switch item.Valid {
-			case "n": // 数字校验及范围检查
-				valDec, err := decimal.NewFromString(val)
-				if err != nil {
-					nlog.Pick().WithContext(ctx).Warnf("数字格式错误 ID:%s Val:%s", item.ID, val)
-					return comm.CodeParameterInvalid
-				}
-				if item.NumberRange != nil {
-					minDec, _ := decimal.NewFromString(item.NumberRange.Min)
-					maxDec, _ := decimal.NewFromString(item.NumberRange.Max)
-					if valDec.LessThan(minDec) || valDec.GreaterThan(maxDec) {
-						nlog.Pick().WithContext(ctx).Warnf("数字超出范围 ID:%s Val:%s", item.ID, val)
-						return comm.CodeParameterInvalid
-					}
-				}
-			case "m": // 手机号校验
-				if !regexMobile.MatchString(val) {
-					nlog.Pick().WithContext(ctx).Warnf("手机号格式错误 ID:%s Val:%s", item.ID, val)
-					return comm.CodeParameterInvalid
-				}
-			case "e": // 邮箱校验
-				if !regexEmail.MatchString(val) {
-					nlog.Pick().WithContext(ctx).Warnf("邮箱格式错误 ID:%s Val:%s", item.ID, val)
-					return comm.CodeParameterInvalid
-				}
-			case "idcard": // 身份证号校验
-				if !regexIDCard.MatchString(val) {
-					nlog.Pick().WithContext(ctx).Warnf("身份证号格式错误 ID:%s Val:%s", item.ID, val)
-					return comm.CodeParameterInvalid
-				}
-			default: // 普通文本校验
-				if item.TextRange != nil {
-					l := len([]rune(val))
-					if l < item.TextRange.Min || l > item.TextRange.Max {
-						nlog.Pick().WithContext(ctx).Warnf("文本长度超出范围 ID:%s Val:%s", item.ID, val)
-						return comm.CodeParameterInvalid
-					}
-				}
-				if item.Regex != "" {
-					if match, _ := regexp.MatchString(item.Regex, val); !match {
-						nlog.Pick().WithContext(ctx).Warnf("文本格式错误 ID:%s Val:%s", item.ID, val)
-						return comm.CodeParameterInvalid
-					}
-				}
-			}
-		} else if item.IsUploadType() {
-			files := strings.Split(val, ",")
-			if item.MaxFileNum > 0 && len(files) > item.MaxFileNum {
-				nlog.Pick().WithContext(ctx).Warnf("上传文件数量超出限制 ID:%s", item.ID)
-				return comm.CodeParameterInvalid
-			}
+	// 测验判分
+	var score int
+	if comm.SurveyType(survey.Type) == comm.SurveyTypeQuiz && surveySchema.IsQuiz() {
+		var grades []schema.QuestionGrade
+		score, grades = surveySchema.Grade(answerMap)
+		s.Response.Quiz = buildQuizResult(surveySchema, score, grades)
+	}
 
-			for _, f := range files {
-				ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(f), "."))
-				switch item.UploadType {
-				case "image":
-					if !slices.Contains([]string{"jpg", "jpeg", "png", "webp"}, ext) {
-						nlog.Pick().WithContext(ctx).Warnf("上传图片格式错误 ID:%s Ext:%s", item.ID, ext)
-						return comm.CodeParameterInvalid
-					}
-				case "file":
-					if len(item.AllowedFileType) > 0 && !slices.Contains(item.AllowedFileType, ext) {
-						nlog.Pick().WithContext(ctx).Warnf("上传文件格式错误 ID:%s Ext:%s", item.ID, ext)
-						return comm.CodeParameterInvalid
-					}
-				}
-			}
-		}
+	// 敏感答案加密存储
+	resultItems, err := encryptSensitive(compiled.SensitiveIDs, req.Result)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("敏感答案加密失败")
+		return comm.CodeUnknownError
 	}
 
 	// 答卷结果序列化
-	data, err := sonic.MarshalString(req.Result)
+	data, err := sonic.MarshalString(resultItems)
 	if err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("答卷结果序列化失败")
 		return comm.CodeDataParseError
 	}
 
+	// 异步提交 写入提交队列后返回提交凭证
+	if surveySchema.BaseConf.AsyncSubmit {
+		ticket, code := submitAsync(ctx, &surveySchema.BaseConf, cache.SubmitMessage{
+			SurveyID:   survey.ID,
+			Username:   username,
+			Data:       data,
+			Score:      int32(score),
+			AccessCode: lo.Ternary(surveySchema.BaseConf.AccessMode == comm.AccessModeCode, comm.NormalizeAccessCode(req.AccessCode), ""),
+			Stats:      statsRefs,
+		}, now)
+		s.Response.Ticket = ticket
+		return code
+	}
+
 	// 排序 避免死锁
 	slices.SortFunc(statsUpdates, func(a, b repo.StatsUpdate) int {
 		if c := strings.Compare(a.QuestionID, b.QuestionID); c != 0 {
@@ -278,17 +202,30 @@ func (s *SubmitApi) Run(ctx *gin.Context) kit.Code {
 		return strings.Compare(a.OptionID, b.OptionID)
 	})
 
-	// 事务 创建答卷 -> 更新统计数据
+	// 事务 创建答卷 -> 使用一次性访问码 -> 更新统计数据
 	err = repo.Transaction(func(tx *query.Query) error {
 		// 创建答卷
-		if err := repo.NewResultRepo(tx).Create(ctx, &model.Result{
+		result := &model.Result{
 			Username: username,
 			SurveyID: survey.ID,
 			Data:     data,
-		}); err != nil {
+			Score:    int32(score),
+		}
+		if err := repo.NewResultRepo(tx).Create(ctx, result); err != nil {
 			return err
 		}
 
+		// 使用一次性访问码 并发提交时仅一个请求可使用成功
+		if surveySchema.BaseConf.AccessMode == comm.AccessModeCode {
+			rows, err := repo.NewSurveyAccessCodeRepo(tx).Consume(ctx, survey.ID, comm.NormalizeAccessCode(req.AccessCode), result.ID, now.UnixMilli())
+			if err != nil {
+				return err
+			}
+			if rows == 0 {
+				return errAccessCodeUsed
+			}
+		}
+
 		// 更新统计数据
 		if len(statsUpdates) > 0 {
 			if _, err := repo.NewStatsRepo(tx).BatchIncr(ctx, survey.ID, statsUpdates); err != nil {
@@ -298,6 +235,9 @@ func (s *SubmitApi) Run(ctx *gin.Context) kit.Code {
 
 		return nil
 	})
+	if errors.Is(err, errAccessCodeUsed) {
+		return comm.CodeSurveyAccessDenied
+	}
 	if err != nil {
 		nlog.Pick().WithContext(ctx).WithError(err).Error("提交问卷失败")
 		return comm.CodeDatabaseError
@@ -306,6 +246,58 @@ func (s *SubmitApi) Run(ctx *gin.Context) kit.Code {
 	return comm.CodeOK
 }
 
+// encryptSensitive 加密敏感信息题目的答案 未配置加密密钥时原样返回
+func encryptSensitive(sensitiveIDs []string, items []comm.ResultItem) ([]comm.ResultItem, error) {
+	if len(sensitiveIDs) == 0 || !comm.AnswerKeyring.Enabled() {
+		return items, nil
+	}
+
+	res := make([]comm.ResultItem, len(items))
+	for i, item := range items {
+		res[i] = item
+		if item.Answer == "" || !slices.Contains(sensitiveIDs, item.QuestionID) {
+			continue
+		}
+		encrypted, err := comm.AnswerKeyring.Encrypt(item.Answer)
+		if err != nil {
+			return nil, err
+		}
+		res[i].Answer = encrypted
+	}
+	return res, nil
+}
+
+// buildQuizResult 按测验配置构建答题者可见的测验结果
+func buildQuizResult(surveySchema *schema.SurveySchema, score int, grades []schema.QuestionGrade) *QuizResult {
+	conf := surveySchema.QuizConf
+	if !conf.ShowScore && !conf.ShowAnswer {
+		return nil
+	}
+
+	res := &QuizResult{}
+	if conf.ShowScore {
+		fullScore := surveySchema.FullScore()
+		res.Score = &score
+		res.FullScore = &fullScore
+	}
+	if conf.ShowAnswer {
+		itemMap := lo.KeyBy(surveySchema.QuestionConf.Items, func(item schema.QuestionItem) string {
+			return item.ID
+		})
+		res.Answers = lo.Map(grades, func(g schema.QuestionGrade, _ int) QuizAnswer {
+			item := itemMap[g.QuestionID]
+			return QuizAnswer{
+				ID:        g.QuestionID,
+				Correct:   g.Correct,
+				Score:     g.Score,
+				FullScore: g.FullScore,
+				Answers:   item.CorrectAnswers(),
+			}
+		})
+	}
+	return res
+}
+
 // Init Api初始化 进行参数校验和绑定
 func (s *SubmitApi) Init(ctx *gin.Context) (err error) {
 	err = ctx.ShouldBindJSON(&s.Request.Body)
@@ -325,6 +317,8 @@ func hfSubmit(ctx *gin.Context) {
 		return
 	}
 	code := api.Run(ctx)
+	// 问卷不存在时不按请求中的问卷ID统计 避免随机ID导致指标膨胀
+	comm.ObserveSubmit(lo.Ternary(code == comm.CodeDataNotFound, 0, api.Request.Body.ID), code)
 	if !ctx.IsAborted() {
 		if code == comm.CodeOK {
 			reply.Success(ctx, api.Response)
@@ -0,0 +1,123 @@
+package survey
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// submitAsync 将答卷写入提交队列 返回提交凭证
+// 使用一次性访问码时先占用访问码 保证同一访问码仅一份答卷排队 提交失败时释放
+func submitAsync(ctx *gin.Context, conf *schema.BaseConf, msg cache.SubmitMessage, now time.Time) (string, kit.Code) {
+	queue := cache.NewSubmitQueueCache()
+	msg.Ticket = uuid.NewString()
+	msg.SubmittedAt = now.UnixMilli()
+
+	if msg.AccessCode == "" {
+		return enqueueSubmit(ctx, queue, conf, msg, now)
+	}
+	ok, err := queue.ClaimCode(ctx, msg.SurveyID, msg.AccessCode)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("占用访问码失败")
+		return "", comm.CodeRedisError
+	}
+	if !ok {
+		return "", comm.CodeSurveyAccessDenied
+	}
+	ticket, code := enqueueSubmit(ctx, queue, conf, msg, now)
+	if code != comm.CodeOK {
+		if err := queue.ReleaseCode(ctx, msg.SurveyID, msg.AccessCode); err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("释放访问码失败")
+		}
+	}
+	return ticket, code
+}
+
+// enqueueSubmit 写入提交队列
+// 设置提交限制时先预占提交次数 再结合已入库的答卷检查 保证并发提交及排队中的答卷均计入限制
+func enqueueSubmit(ctx *gin.Context, queue *cache.SubmitQueueCache, conf *schema.BaseConf, msg cache.SubmitMessage, now time.Time) (string, kit.Code) {
+	if msg.Username != "" && (conf.TotalLimit > 0 || conf.DailyLimit > 0) {
+		total, daily, err := queue.Reserve(ctx, msg.SurveyID, msg.Username, now)
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("预占提交次数失败")
+			return "", comm.CodeRedisError
+		}
+		msg.Reserved = true
+
+		code := checkReservedLimit(ctx, conf, msg.SurveyID, msg.Username, total, daily, now)
+		if code != comm.CodeOK {
+			releaseReserved(ctx, queue, msg.SurveyID, msg.Username, now)
+			return "", code
+		}
+	}
+
+	if err := queue.Enqueue(ctx, msg); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("写入提交队列失败")
+		if msg.Reserved {
+			releaseReserved(ctx, queue, msg.SurveyID, msg.Username, now)
+		}
+		return "", comm.CodeRedisError
+	}
+	return msg.Ticket, comm.CodeOK
+}
+
+// checkSubmitLimit 同步提交时检查提交限制 排队中答卷预占的提交次数一并计入
+// 关闭异步提交后队列中仍有答卷时 避免超出提交限制
+func checkSubmitLimit(ctx *gin.Context, conf *schema.BaseConf, surveyID int64, username string, now time.Time) kit.Code {
+	if conf.TotalLimit <= 0 && conf.DailyLimit <= 0 {
+		return comm.CodeOK
+	}
+	total, daily, err := cache.NewSubmitQueueCache().GetReserved(ctx, surveyID, username, now)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询预占提交次数失败")
+		return comm.CodeRedisError
+	}
+	// 计入本次提交
+	return checkReservedLimit(ctx, conf, surveyID, username, total+1, daily+1, now)
+}
+
+// checkReservedLimit 检查已入库答卷与排队中答卷合计是否超出提交限制
+// 须在预占之后查询数据库 入库与扣减预占之间的答卷会被重复计数 仅可能多拒绝 不会超出限制
+func checkReservedLimit(ctx *gin.Context, conf *schema.BaseConf, surveyID int64, username string, total, daily int64, now time.Time) kit.Code {
+	if conf.TotalLimit > 0 {
+		count, err := repo.NewResultRepo().CountByUser(ctx, surveyID, username, nil)
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷总提交次数失败")
+			return comm.CodeDatabaseError
+		}
+		if count+total > conf.TotalLimit {
+			return comm.CodeSurveySubmitLimit
+		}
+	}
+
+	if conf.DailyLimit > 0 {
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		count, err := repo.NewResultRepo().CountByUser(ctx, surveyID, username, &repo.TimeRange{
+			Start: start,
+			End:   start.Add(24 * time.Hour),
+		})
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询问卷今日提交次数失败")
+			return comm.CodeDatabaseError
+		}
+		if count+daily > conf.DailyLimit {
+			return comm.CodeSurveySubmitLimit
+		}
+	}
+
+	return comm.CodeOK
+}
+
+func releaseReserved(ctx *gin.Context, queue *cache.SubmitQueueCache, surveyID int64, username string, now time.Time) {
+	if err := queue.Release(ctx, surveyID, username, now); err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("扣减预占提交次数失败")
+	}
+}
@@ -0,0 +1,83 @@
+package survey
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/kit"
+	"github.com/zjutjh/mygo/nlog"
+	"github.com/zjutjh/mygo/swagger"
+
+	"app/comm"
+	"app/dao/cache"
+)
+
+// TicketHandler API router注册点
+func TicketHandler() gin.HandlerFunc {
+	api := TicketApi{}
+	swagger.CM[runtime.FuncForPC(reflect.ValueOf(hfTicket).Pointer()).Name()] = api
+	return hfTicket
+}
+
+type TicketApi struct {
+	Info     struct{}          `name:"查询提交状态" desc:"按提交凭证查询异步提交的答卷入库结果"`
+	Request  TicketApiRequest  // API请求参数 (Uri/Header/Query/Body)
+	Response TicketApiResponse // API响应数据 (Body中的Data部分)
+}
+
+type TicketApiRequest struct {
+	Query struct {
+		Ticket string `form:"ticket" binding:"required,uuid" desc:"提交凭证"`
+	}
+}
+
+type TicketApiResponse struct {
+	Status comm.SubmitStatus `json:"status" desc:"提交状态 pending:排队中 done:已入库 failed:入库失败"`
+	Reason string            `json:"reason,omitempty" desc:"失败原因 status=failed时返回"`
+}
+
+// Run Api业务逻辑执行点
+func (t *TicketApi) Run(ctx *gin.Context) kit.Code {
+	ticket, err := cache.NewSubmitQueueCache().GetTicket(ctx, t.Request.Query.Ticket)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询提交凭证失败")
+		return comm.CodeRedisError
+	}
+	if ticket == nil {
+		return comm.CodeDataNotFound
+	}
+
+	t.Response.Status = ticket.Status
+	t.Response.Reason = ticket.Reason
+	return comm.CodeOK
+}
+
+// Init Api初始化 进行参数校验和绑定
+func (t *TicketApi) Init(ctx *gin.Context) (err error) {
+	err = ctx.ShouldBindQuery(&t.Request.Query)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// hfTicket API执行入口
+func hfTicket(ctx *gin.Context) {
+	api := &TicketApi{}
+	err := api.Init(ctx)
+	if err != nil {
+		nlog.Pick().WithContext(ctx).WithError(err).Warn("参数绑定校验错误")
+		reply.Fail(ctx, comm.CodeParameterInvalid)
+		return
+	}
+	code := api.Run(ctx)
+	if !ctx.IsAborted() {
+		if code == comm.CodeOK {
+			reply.Success(ctx, api.Response)
+		} else {
+			reply.Fail(ctx, code)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/cron"
+	"app/dao/model"
+	"app/dao/repo"
+)
+
+// ArchiveRun 将指定问卷的答卷移入归档表 用法: archive <问卷ID>...
+func ArchiveRun(_ *cobra.Command, args []string) error {
+	return eachArchiveSurvey(args, func(ctx context.Context, survey *model.Survey) error {
+		if survey.ArchivedAt > 0 && survey.ArchivingAt > 0 {
+			return errors.New("问卷答卷恢复未完成 请执行archive-restore继续恢复")
+		}
+		if survey.ArchivedAt > 0 {
+			nlog.Pick().Infof("问卷答卷已归档 跳过 ID:%d", survey.ID)
+			return nil
+		}
+		if err := cron.ArchiveSurvey(ctx, survey, time.Now()); err != nil {
+			return err
+		}
+		nlog.Pick().Infof("问卷答卷归档完成 ID:%d", survey.ID)
+		return nil
+	})
+}
+
+// ArchiveRestoreRun 将指定问卷的答卷从归档表移回答卷表 用法: archive-restore <问卷ID>...
+// 恢复后若问卷仍满足自动归档条件 将在下次归档任务中再次归档
+func ArchiveRestoreRun(_ *cobra.Command, args []string) error {
+	return eachArchiveSurvey(args, func(ctx context.Context, survey *model.Survey) error {
+		// 归档中断的问卷同样可恢复 将已移入归档表的答卷移回
+		if survey.ArchivedAt == 0 && survey.ArchivingAt == 0 {
+			nlog.Pick().Infof("问卷答卷未归档 跳过 ID:%d", survey.ID)
+			return nil
+		}
+		if err := cron.RestoreSurvey(ctx, survey); err != nil {
+			return err
+		}
+		nlog.Pick().Infof("问卷答卷恢复完成 ID:%d", survey.ID)
+		return nil
+	})
+}
+
+// eachArchiveSurvey 解析问卷ID参数并逐个处理 单个问卷失败不影响其他问卷
+func eachArchiveSurvey(args []string, fc func(ctx context.Context, survey *model.Survey) error) error {
+	if len(args) == 0 {
+		return errors.New("请指定问卷ID")
+	}
+	ids := make([]int64, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("问卷ID非法: %s", arg)
+		}
+		ids = append(ids, id)
+	}
+
+	ctx := context.Background()
+	failed := 0
+	for _, id := range ids {
+		survey, err := repo.NewSurveyRepo().FindByID(ctx, id)
+		if err == nil {
+			err = fc(ctx, survey)
+		}
+		if err != nil {
+			nlog.Pick().WithError(err).Errorf("处理问卷答卷失败 ID:%d", id)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d个问卷处理失败", failed)
+	}
+	return nil
+}
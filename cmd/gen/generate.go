@@ -16,6 +16,15 @@ var tables = []string{
 	"survey",
 	"result",
 	"stats",
+	"cascade_dataset",
+	"question_bank",
+	"admin_invite",
+	"admin_invite_use",
+	"audit_log",
+	"admin_recovery_code",
+	"system_setting",
+	"survey_access_code",
+	"survey_allowlist",
 }
 
 func main() {
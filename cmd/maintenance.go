@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/repo"
+)
+
+// MaintenanceRun 设置或查询全局维护模式 用法: maintenance on|off|status
+func MaintenanceRun(_ *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("用法: maintenance on|off|status")
+	}
+	ctx := context.Background()
+	maintenance := cache.NewMaintenanceCache()
+
+	var enabled bool
+	switch args[0] {
+	case "on":
+		enabled = true
+		if err := maintenance.Enable(ctx, time.Now()); err != nil {
+			return err
+		}
+		nlog.Pick().Info("维护模式已开启")
+	case "off":
+		if err := maintenance.Disable(ctx); err != nil {
+			return err
+		}
+		nlog.Pick().Info("维护模式已关闭")
+	case "status":
+		since, err := maintenance.Get(ctx)
+		if err != nil {
+			return err
+		}
+		if since == nil {
+			nlog.Pick().Info("维护模式未开启")
+		} else {
+			nlog.Pick().Infof("维护模式已开启 开启时间:%s", since.Format(time.DateTime))
+		}
+		return nil
+	default:
+		return errors.New("用法: maintenance on|off|status")
+	}
+
+	// 命令行操作无管理员 维护期间数据库可能不可用 记录失败不影响维护模式切换
+	err := repo.NewAuditLogRepo().Record(ctx, repo.AuditEntry{
+		Action:     comm.AuditActionMaintenance,
+		TargetType: comm.AuditTargetSystem,
+		Detail: map[string]any{
+			"enabled": enabled,
+		},
+	})
+	if err != nil {
+		nlog.Pick().WithError(err).Error("记录审计日志失败")
+	}
+	return nil
+}
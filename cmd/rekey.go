@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bytedance/sonic"
+	"github.com/spf13/cobra"
+	"github.com/zjutjh/mygo/nlog"
+	"gorm.io/gorm"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// RekeyRun 使用当前密钥重新加密答卷中的敏感答案及管理员TOTP密钥 用于密钥轮换后迁移旧密文及加密历史明文
+func RekeyRun(_ *cobra.Command, _ []string) error {
+	if !comm.AnswerKeyring.Enabled() {
+		return errors.New("未配置当前加密密钥 biz.crypto.current_key")
+	}
+
+	ctx := context.Background()
+	surveyRepo := repo.NewSurveyRepo()
+	sensitiveMap := make(map[int64][]string) // map[SurveyID]敏感信息题目ID列表
+	updated, failed := 0, 0
+
+	rekeyBatch := func(resultRepo *repo.ResultRepo, list []*model.Result) error {
+		for _, res := range list {
+			// 查询问卷敏感信息题目 问卷已删除时仅处理已加密的答案
+			sensitiveIDs, ok := sensitiveMap[res.SurveyID]
+			if !ok {
+				survey, err := surveyRepo.FindByID(ctx, res.SurveyID)
+				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+					return err
+				}
+				if survey != nil {
+					var surveySchema schema.SurveySchema
+					if err := sonic.UnmarshalString(survey.Schema, &surveySchema); err != nil {
+						return fmt.Errorf("问卷结构反序列化失败 ID:%d: %w", survey.ID, err)
+					}
+					sensitiveIDs = surveySchema.SensitiveIDs()
+				}
+				sensitiveMap[res.SurveyID] = sensitiveIDs
+			}
+
+			// 重新加密
+			var items []comm.ResultItem
+			if err := sonic.UnmarshalString(res.Data, &items); err != nil {
+				nlog.Pick().WithError(err).Errorf("答卷数据解析失败 ID:%d", res.ID)
+				failed++
+				continue
+			}
+			changed, err := rekeyItems(items, sensitiveIDs)
+			if err != nil {
+				nlog.Pick().WithError(err).Errorf("答卷重新加密失败 ID:%d", res.ID)
+				failed++
+				continue
+			}
+			if !changed {
+				continue
+			}
+
+			data, err := sonic.MarshalString(items)
+			if err != nil {
+				return err
+			}
+			if err := resultRepo.UpdateData(ctx, res.ID, data); err != nil {
+				return err
+			}
+			updated++
+		}
+		return nil
+	}
+
+	// 依次处理答卷表及归档表
+	for _, resultRepo := range []*repo.ResultRepo{repo.NewResultRepo(), repo.NewResultRepo().Archive()} {
+		err := resultRepo.FindAllInBatches(ctx, 500, func(list []*model.Result) error {
+			return rekeyBatch(resultRepo, list)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	adminUpdated, adminFailed, err := rekeyTotpSecrets(ctx)
+	if err != nil {
+		return err
+	}
+
+	nlog.Pick().Infof("重新加密完成 更新答卷%d份 失败%d份 更新TOTP密钥%d个 失败%d个", updated, failed, adminUpdated, adminFailed)
+	if failed > 0 || adminFailed > 0 {
+		return fmt.Errorf("%d份答卷 %d个TOTP密钥重新加密失败", failed, adminFailed)
+	}
+	return nil
+}
+
+// rekeyTotpSecrets 使用当前密钥重新加密管理员TOTP密钥 返回更新及失败的数量
+func rekeyTotpSecrets(ctx context.Context) (updated, failed int, err error) {
+	adminRepo := repo.NewAdminRepo()
+	admins, err := adminRepo.FindTotpEnabled(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, admin := range admins {
+		if !comm.AnswerKeyring.NeedRekey(admin.TotpSecret) {
+			continue
+		}
+		secret, err := comm.AnswerKeyring.Decrypt(admin.TotpSecret)
+		if err != nil {
+			nlog.Pick().WithError(err).Errorf("TOTP密钥解密失败 管理员ID:%d", admin.ID)
+			failed++
+			continue
+		}
+		encrypted, err := comm.AnswerKeyring.Encrypt(secret)
+		if err != nil {
+			return updated, failed, err
+		}
+		if _, err := adminRepo.UpdateTotpSecret(ctx, admin.ID, encrypted); err != nil {
+			return updated, failed, err
+		}
+		updated++
+	}
+	return updated, failed, nil
+}
+
+// rekeyItems 使用当前密钥重新加密敏感信息题目答案及旧密钥密文 返回是否有变更
+func rekeyItems(items []comm.ResultItem, sensitiveIDs []string) (bool, error) {
+	changed := false
+	for i := range items {
+		item := &items[i]
+		if !comm.IsEncrypted(item.Answer) && !slices.Contains(sensitiveIDs, item.QuestionID) {
+			continue
+		}
+		if !comm.AnswerKeyring.NeedRekey(item.Answer) {
+			continue
+		}
+		plaintext, err := comm.AnswerKeyring.Decrypt(item.Answer)
+		if err != nil {
+			return false, fmt.Errorf("题目%s: %w", item.QuestionID, err)
+		}
+		item.Answer, err = comm.AnswerKeyring.Encrypt(plaintext)
+		if err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
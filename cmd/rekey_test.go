@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"app/comm"
+)
+
+func useTestKeyring(t *testing.T, current string, keys map[string]byte) *comm.Keyring {
+	t.Helper()
+	conf := comm.CryptoConfig{CurrentKey: current, Keys: make(map[string]string, len(keys))}
+	for id, b := range keys {
+		conf.Keys[id] = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+	}
+	k, err := comm.NewKeyring(conf)
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	old := comm.AnswerKeyring
+	comm.AnswerKeyring = k
+	t.Cleanup(func() {
+		comm.AnswerKeyring = old
+	})
+	return k
+}
+
+func mustEncrypt(t *testing.T, k *comm.Keyring, plaintext string) string {
+	t.Helper()
+	encrypted, err := k.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	return encrypted
+}
+
+func TestRekeyItems(t *testing.T) {
+	oldKeyring := useTestKeyring(t, "k1", map[string]byte{"k1": 1})
+	oldMobile := mustEncrypt(t, oldKeyring, "13812345678")
+	oldName := mustEncrypt(t, oldKeyring, "张三")
+	keyring := useTestKeyring(t, "k2", map[string]byte{"k1": 1, "k2": 2})
+	currentMobile := mustEncrypt(t, keyring, "13812345678")
+
+	tests := []struct {
+		name        string
+		items       []comm.ResultItem
+		want        []string // 解密后的答案
+		wantChanged bool
+		wantKeys    []string // 答案使用的密钥ID 空字符串表示明文
+	}{
+		{
+			name:        "敏感题目明文加密",
+			items:       []comm.ResultItem{{QuestionID: "m", Answer: "13812345678"}, {QuestionID: "t", Answer: "备注"}},
+			want:        []string{"13812345678", "备注"},
+			wantChanged: true,
+			wantKeys:    []string{"k2", ""},
+		},
+		{
+			name:        "旧密钥密文重新加密 包括已不再标记为敏感的题目",
+			items:       []comm.ResultItem{{QuestionID: "m", Answer: oldMobile}, {QuestionID: "t", Answer: oldName}},
+			want:        []string{"13812345678", "张三"},
+			wantChanged: true,
+			wantKeys:    []string{"k2", "k2"},
+		},
+		{
+			name:        "当前密钥密文及空答案不变",
+			items:       []comm.ResultItem{{QuestionID: "m", Answer: currentMobile}, {QuestionID: "id", Answer: ""}},
+			want:        []string{"13812345678", ""},
+			wantChanged: false,
+			wantKeys:    []string{"k2", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := rekeyItems(tt.items, []string{"m", "id"})
+			if err != nil {
+				t.Fatalf("rekeyItems() error = %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("rekeyItems() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			for i, item := range tt.items {
+				if key := tt.wantKeys[i]; key == "" {
+					if comm.IsEncrypted(item.Answer) {
+						t.Errorf("item %s = %q, want plaintext", item.QuestionID, item.Answer)
+					}
+				} else if !strings.HasPrefix(item.Answer, "enc:"+key+":") {
+					t.Errorf("item %s = %q, want key %s", item.QuestionID, item.Answer, key)
+				}
+				got, err := keyring.Decrypt(item.Answer)
+				if err != nil {
+					t.Fatalf("Decrypt() error = %v", err)
+				}
+				if got != tt.want[i] {
+					t.Errorf("item %s decrypted = %q, want %q", item.QuestionID, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRekeyItemsUnknownKey(t *testing.T) {
+	unknown := mustEncrypt(t, useTestKeyring(t, "k9", map[string]byte{"k9": 9}), "13812345678")
+	useTestKeyring(t, "k2", map[string]byte{"k2": 2})
+
+	items := []comm.ResultItem{{QuestionID: "m", Answer: unknown}}
+	if _, err := rekeyItems(items, []string{"m"}); !errors.Is(err, comm.ErrUnknownKey) {
+		t.Errorf("rekeyItems() error = %v, want ErrUnknownKey", err)
+	}
+	if items[0].Answer != unknown {
+		t.Error("rekeyItems() modified answer encrypted with unknown key")
+	}
+}
@@ -0,0 +1,328 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/zjutjh/mygo/nlog"
+	"gorm.io/gorm"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/model"
+	"app/dao/query"
+	"app/dao/repo"
+	"app/schema"
+)
+
+const (
+	submitBatchSize  = 200              // 每批入库答卷数量
+	submitReadBlock  = 2 * time.Second  // 队列为空时的阻塞等待时长
+	submitClaimIdle  = 5 * time.Minute  // 认领其他消费者未确认答卷的空闲时长
+	submitClaimEvery = 30 * time.Second // 认领未确认答卷的间隔
+	submitPauseWait  = 5 * time.Second  // 维护模式下检查是否关闭的间隔
+
+	submitMaxDeliveries = 5 // 答卷最大投递次数 超过后判定入库失败 避免无法入库的答卷无限重试
+)
+
+// errSubmitAccessCodeUsed 一次性访问码已被使用 用于回滚入库事务
+var errSubmitAccessCodeUsed = errors.New("access code already used")
+
+// SubmitWorkerRun 消费异步提交队列 将答卷批量入库 收到退出信号后处理完当前批次退出
+// 可多实例运行 其他实例异常退出后其未确认的答卷由存活实例认领 维护模式下暂停消费 答卷保留在队列中
+func SubmitWorkerRun(_ *cobra.Command, _ []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	hostname, _ := os.Hostname()
+	consumer := fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	queue := cache.NewSubmitQueueCache()
+	if err := queue.CreateGroup(ctx); err != nil {
+		return fmt.Errorf("创建提交队列消费组失败: %w", err)
+	}
+	nlog.Pick().Infof("异步提交入库已启动 消费者:%s", consumer)
+
+	maintenance := cache.NewMaintenanceCache()
+	var lastClaim time.Time
+	paused := false
+	for ctx.Err() == nil {
+		since, err := maintenance.Get(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			nlog.Pick().WithError(err).Error("查询维护模式失败")
+			time.Sleep(time.Second)
+			continue
+		}
+		if since != nil {
+			if !paused {
+				paused = true
+				nlog.Pick().Info("维护模式已开启 暂停异步提交入库")
+			}
+			time.Sleep(submitPauseWait)
+			continue
+		}
+		if paused {
+			paused = false
+			nlog.Pick().Info("维护模式已关闭 恢复异步提交入库")
+		}
+
+		var entries []cache.SubmitEntry
+		if time.Since(lastClaim) >= submitClaimEvery {
+			lastClaim = time.Now()
+			entries, err = queue.Claim(ctx, consumer, submitBatchSize, submitClaimIdle)
+		}
+		if err == nil && len(entries) == 0 {
+			entries, err = queue.Read(ctx, consumer, submitBatchSize, submitReadBlock)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			nlog.Pick().WithError(err).Error("读取提交队列失败")
+			time.Sleep(time.Second)
+			continue
+		}
+		if len(entries) > 0 {
+			// 使用独立ctx 保证收到退出信号时当前批次仍可完成入库
+			persistSubmitEntries(context.WithoutCancel(ctx), queue, entries)
+		}
+	}
+
+	nlog.Pick().Info("异步提交入库已退出")
+	return nil
+}
+
+// persistSubmitEntries 批量入库答卷 整批失败时逐条入库 定位无法入库的答卷
+func persistSubmitEntries(ctx context.Context, queue *cache.SubmitQueueCache, all []cache.SubmitEntry) {
+	finished := make(map[string]cache.SubmitTicket) // map[消息ID]提交凭证状态
+	doneTicket := cache.SubmitTicket{Status: comm.SubmitStatusDone}
+
+	// 格式错误的消息直接确认丢弃
+	entries := lo.Filter(all, func(entry cache.SubmitEntry, _ int) bool {
+		if entry.Message == nil {
+			nlog.Pick().Errorf("提交队列消息格式错误 ID:%s", entry.ID)
+			finished[entry.ID] = cache.SubmitTicket{}
+		}
+		return entry.Message != nil
+	})
+
+	// 已入库的答卷 (入库后确认前异常退出) 不再重复入库
+	if len(entries) > 0 {
+		tickets := lo.Map(entries, func(entry cache.SubmitEntry, _ int) string {
+			return entry.Message.Ticket
+		})
+		existing, err := repo.NewResultRepo().FindExistingTickets(ctx, tickets)
+		if err != nil {
+			nlog.Pick().WithError(err).Error("查询已入库提交凭证失败")
+			return
+		}
+		entries = lo.Filter(entries, func(entry cache.SubmitEntry, _ int) bool {
+			if slices.Contains(existing, entry.Message.Ticket) {
+				finished[entry.ID] = doneTicket
+				return false
+			}
+			return true
+		})
+	}
+
+	// 问卷已删除、已暂停提交或答卷已归档时不再入库 与同步提交一致 释放占用的访问码及预占的提交次数
+	if len(entries) > 0 {
+		surveyIDs := lo.Uniq(lo.Map(entries, func(entry cache.SubmitEntry, _ int) int64 {
+			return entry.Message.SurveyID
+		}))
+		surveys, err := repo.NewSurveyRepo().FindListByIDs(ctx, surveyIDs)
+		if err != nil {
+			nlog.Pick().WithError(err).Error("查询问卷失败")
+			return
+		}
+		// map[SurveyID]失败原因 未查询到的问卷已在排队期间删除
+		rejected := lo.SliceToMap(surveyIDs, func(id int64) (int64, string) {
+			return id, comm.CodeDataNotFound.Message
+		})
+		for _, survey := range surveys {
+			delete(rejected, survey.ID)
+			switch {
+			case survey.FrozenAt > 0:
+				rejected[survey.ID] = comm.CodeSurveySubmitFrozen.Message
+			case survey.ArchivedAt > 0 || survey.ArchivingAt > 0:
+				rejected[survey.ID] = comm.CodeSurveyTimeInvalid.Message
+			}
+		}
+		entries = lo.Filter(entries, func(entry cache.SubmitEntry, _ int) bool {
+			reason, ok := rejected[entry.Message.SurveyID]
+			if ok {
+				finished[entry.ID] = cache.SubmitTicket{Status: comm.SubmitStatusFailed, Reason: reason}
+				releaseSubmitCode(ctx, queue, entry.Message)
+			}
+			return !ok
+		})
+	}
+
+	if err := saveSubmitEntries(ctx, entries); err == nil {
+		for _, entry := range entries {
+			finished[entry.ID] = doneTicket
+		}
+	} else {
+		nlog.Pick().WithError(err).Warnf("批量入库答卷失败 逐条重试 数量:%d", len(entries))
+		var retries []string
+		for _, entry := range entries {
+			err := saveSubmitEntries(ctx, []cache.SubmitEntry{entry})
+			switch {
+			case err == nil, errors.Is(err, gorm.ErrDuplicatedKey):
+				finished[entry.ID] = doneTicket
+			case errors.Is(err, errSubmitAccessCodeUsed):
+				finished[entry.ID] = cache.SubmitTicket{
+					Status: comm.SubmitStatusFailed,
+					Reason: comm.CodeSurveyAccessDenied.Message,
+				}
+			default:
+				// 暂不确认 超过认领空闲时长后重试
+				nlog.Pick().WithError(err).Errorf("答卷入库失败 Ticket:%s", entry.Message.Ticket)
+				retries = append(retries, entry.ID)
+			}
+		}
+
+		// 超过最大投递次数的答卷判定入库失败 释放占用的访问码 用户可重新提交
+		if len(retries) > 0 {
+			deliveries, err := queue.Deliveries(ctx, retries...)
+			if err != nil {
+				nlog.Pick().WithError(err).Error("查询答卷投递次数失败")
+			}
+			for _, entry := range entries {
+				if count, ok := deliveries[entry.ID]; !ok || count < submitMaxDeliveries {
+					continue
+				}
+				nlog.Pick().Errorf("答卷超过最大投递次数 判定入库失败 Ticket:%s", entry.Message.Ticket)
+				finished[entry.ID] = cache.SubmitTicket{
+					Status: comm.SubmitStatusFailed,
+					Reason: comm.CodeDatabaseError.Message,
+				}
+				releaseSubmitCode(ctx, queue, entry.Message)
+			}
+		}
+	}
+
+	// 更新提交凭证状态 -> 确认消息 -> 扣减预占的提交次数
+	// 确认前异常退出时消息将被重新处理 先确认再扣减 避免重复扣减导致超出提交限制
+	var acked []*cache.SubmitMessage
+	for _, entry := range all {
+		ticket, ok := finished[entry.ID]
+		if !ok || entry.Message == nil {
+			continue
+		}
+		if err := queue.SetTicket(ctx, entry.Message.Ticket, ticket); err != nil {
+			nlog.Pick().WithError(err).Errorf("更新提交凭证状态失败 Ticket:%s", entry.Message.Ticket)
+		}
+		acked = append(acked, entry.Message)
+	}
+	if err := queue.Ack(ctx, lo.Keys(finished)...); err != nil {
+		nlog.Pick().WithError(err).Error("确认提交队列消息失败")
+		return
+	}
+	for _, msg := range acked {
+		if !msg.Reserved {
+			continue
+		}
+		if err := queue.Release(ctx, msg.SurveyID, msg.Username, time.UnixMilli(msg.SubmittedAt)); err != nil {
+			nlog.Pick().WithError(err).Errorf("扣减预占提交次数失败 Ticket:%s", msg.Ticket)
+		}
+	}
+}
+
+// releaseSubmitCode 释放未能入库答卷占用的一次性访问码 用户可使用该访问码重新提交
+func releaseSubmitCode(ctx context.Context, queue *cache.SubmitQueueCache, msg *cache.SubmitMessage) {
+	if msg.AccessCode == "" {
+		return
+	}
+	if err := queue.ReleaseCode(ctx, msg.SurveyID, msg.AccessCode); err != nil {
+		nlog.Pick().WithError(err).Errorf("释放访问码失败 Ticket:%s", msg.Ticket)
+	}
+}
+
+// saveSubmitEntries 事务 创建答卷 -> 使用一次性访问码 -> 合并更新统计数据
+func saveSubmitEntries(ctx context.Context, entries []cache.SubmitEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return repo.Transaction(func(tx *query.Query) error {
+		// 创建答卷
+		results := lo.Map(entries, func(entry cache.SubmitEntry, _ int) *model.Result {
+			msg := entry.Message
+			return &model.Result{
+				SurveyID:  msg.SurveyID,
+				Username:  msg.Username,
+				Data:      msg.Data,
+				Score:     msg.Score,
+				Ticket:    &msg.Ticket,
+				CreatedAt: time.UnixMilli(msg.SubmittedAt),
+			}
+		})
+		if err := repo.NewResultRepo(tx).BatchCreate(ctx, results); err != nil {
+			return err
+		}
+
+		// 使用一次性访问码
+		for i, entry := range entries {
+			msg := entry.Message
+			if msg.AccessCode == "" {
+				continue
+			}
+			rows, err := repo.NewSurveyAccessCodeRepo(tx).Consume(ctx, msg.SurveyID, msg.AccessCode, results[i].ID, msg.SubmittedAt)
+			if err != nil {
+				return err
+			}
+			if rows == 0 {
+				return errSubmitAccessCodeUsed
+			}
+		}
+
+		// 合并统计数据 同一答卷重复的统计项仅计一次 与同步提交一致
+		counts := make(map[int64]map[schema.StatsRef]int32) // map[SurveyID]map[统计项]增量
+		for _, entry := range entries {
+			msg := entry.Message
+			if counts[msg.SurveyID] == nil {
+				counts[msg.SurveyID] = make(map[schema.StatsRef]int32)
+			}
+			for _, ref := range lo.Uniq(msg.Stats) {
+				counts[msg.SurveyID][ref]++
+			}
+		}
+
+		// 按问卷及增量分组更新 排序避免死锁
+		statsRepo := repo.NewStatsRepo(tx)
+		surveyIDs := lo.Keys(counts)
+		slices.Sort(surveyIDs)
+		for _, surveyID := range surveyIDs {
+			groups := make(map[int32][]repo.StatsUpdate)
+			for ref, delta := range counts[surveyID] {
+				groups[delta] = append(groups[delta], repo.StatsUpdate{QuestionID: ref.QuestionID, OptionID: ref.OptionID})
+			}
+			deltas := lo.Keys(groups)
+			slices.Sort(deltas)
+			for _, delta := range deltas {
+				updates := groups[delta]
+				slices.SortFunc(updates, func(a, b repo.StatsUpdate) int {
+					if c := strings.Compare(a.QuestionID, b.QuestionID); c != 0 {
+						return c
+					}
+					return strings.Compare(a.OptionID, b.OptionID)
+				})
+				if _, err := statsRepo.BatchIncrBy(ctx, surveyID, updates, delta); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,61 @@
+package comm
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// BloomFilter 布隆过滤器 判定不存在时一定不存在 判定存在时可能误判
+type BloomFilter struct {
+	mu   sync.RWMutex
+	bits []uint64
+	m    uint64 // 位数
+	k    uint64 // 哈希函数个数
+}
+
+// NewBloomFilter 按预期元素数量及误判率创建布隆过滤器
+func NewBloomFilter(n int, fpRate float64) *BloomFilter {
+	n = max(n, 1)
+	m := uint64(math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	m = max((m+63)/64*64, 64)
+	k := uint64(max(math.Round(float64(m)/float64(n)*math.Ln2), 1))
+	return &BloomFilter{
+		bits: make([]uint64, m/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// Add 添加元素
+func (f *BloomFilter) Add(value string) {
+	h1, h2 := bloomHash(value)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.k {
+		pos := (h1 + i*h2) % f.m
+		f.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// Test 判断元素是否可能存在
+func (f *BloomFilter) Test(value string) bool {
+	h1, h2 := bloomHash(value)
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for i := range f.k {
+		pos := (h1 + i*h2) % f.m
+		if f.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHash 计算双重哈希使用的两个哈希值
+func bloomHash(value string) (uint64, uint64) {
+	ha, hb := fnv.New64a(), fnv.New64()
+	_, _ = ha.Write([]byte(value))
+	_, _ = hb.Write([]byte(value))
+	return ha.Sum64(), hb.Sum64() | 1
+}
@@ -33,4 +33,19 @@ var (
 	CodeAdminPasswordError = kit.NewCode(30002, "管理员密码错误")
 	CodeSurveyTimeInvalid  = kit.NewCode(30003, "不在问卷有效期内")
 	CodeSurveySubmitLimit  = kit.NewCode(30004, "超出提交限制")
+	CodeAdminDisabled      = kit.NewCode(30005, "管理员已禁用")
+	CodeInvalidCredentials = kit.NewCode(30006, "用户名或密码错误")
+	CodeLoginLimited       = kit.NewCode(30007, "登录尝试过于频繁，请稍后再试")
+	CodeInviteInvalid      = kit.NewCode(30008, "邀请码无效或已失效")
+	CodeInviteMismatch     = kit.NewCode(30009, "用户名与邀请码不匹配")
+	CodeTotpRequired       = kit.NewCode(30010, "请输入二次验证码")
+	CodeTotpInvalid        = kit.NewCode(30011, "二次验证码错误")
+	CodeTotpNotEnabled     = kit.NewCode(30012, "未启用二次验证")
+	CodeTotpAlreadyEnabled = kit.NewCode(30013, "已启用二次验证")
+	CodeTotpSetupRequired  = kit.NewCode(30014, "请先启用二次验证")
+	CodeSurveyAccessNeeded = kit.NewCode(30015, "请输入问卷访问码")
+	CodeSurveyAccessDenied = kit.NewCode(30016, "问卷访问码错误或已使用")
+	CodeSurveyNotAllowed   = kit.NewCode(30017, "不在问卷答题名单内")
+	CodeSurveySubmitFrozen = kit.NewCode(30018, "问卷已暂停提交")
+	CodeSurveyAccessLimit  = kit.NewCode(30019, "访问码尝试过于频繁，请稍后再试")
 )
@@ -1,8 +1,76 @@
 package comm
 
+import "time"
+
 // BizConf 业务配置
 var BizConf *BizConfig
 
 type BizConfig struct {
-	AdminCreateSecret string `mapstructure:"admin_create_secret"` // 创建管理员密钥
+	AdminBootstrap    bool              `mapstructure:"admin_bootstrap"`     // 是否允许使用创建管理员密钥创建管理员 仅用于初始化部署
+	AdminCreateSecret string            `mapstructure:"admin_create_secret"` // 创建管理员密钥 admin_bootstrap=true时生效
+	LoginGuard        LoginGuardConfig  `mapstructure:"login_guard"`         // 登录防爆破配置
+	Session           SessionConfig     `mapstructure:"session"`             // 登录会话配置
+	Crypto            CryptoConfig      `mapstructure:"crypto"`              // 敏感答案加密配置
+	SurveyCache       SurveyCacheConfig `mapstructure:"survey_cache"`        // 问卷缓存配置
+	Metrics           MetricsConfig     `mapstructure:"metrics"`             // 监控指标配置
+	Tracing           TracingConfig     `mapstructure:"tracing"`             // 链路追踪配置
+	DBReplica         DBReplicaConfig   `mapstructure:"db_replica"`          // 数据库读写分离配置
+	Archive           ArchiveConfig     `mapstructure:"archive"`             // 答卷归档配置
+}
+
+// LoginGuardConfig 登录防爆破配置 按用户名及IP分别计数 同时用于限制问卷访问密码及访问码尝试
+type LoginGuardConfig struct {
+	FreeFails    int           `mapstructure:"free_fails"`     // 不延迟的连续失败次数
+	MaxDelay     time.Duration `mapstructure:"max_delay"`      // 渐进延迟上限 超出免延迟次数后每次失败延迟翻倍
+	UserMaxFails int           `mapstructure:"user_max_fails"` // 单用户名连续失败次数上限 达到后锁定
+	IPMaxFails   int           `mapstructure:"ip_max_fails"`   // 单IP连续失败次数上限 达到后锁定
+	Window       time.Duration `mapstructure:"window"`         // 失败计数有效期 最后一次失败起算
+	LockDuration time.Duration `mapstructure:"lock_duration"`  // 锁定时长
+}
+
+// SessionConfig 登录会话配置 访问Token有效期见jwt_admin/jwt_user配置
+type SessionConfig struct {
+	AdminRefreshExpiration time.Duration `mapstructure:"admin_refresh_expiration"` // 管理员刷新Token有效期
+	UserRefreshExpiration  time.Duration `mapstructure:"user_refresh_expiration"`  // 用户刷新Token有效期
+}
+
+// CryptoConfig 敏感答案及管理员TOTP密钥加密配置 轮换密钥时新增密钥并切换current_key 旧密钥保留至执行rekey命令后
+type CryptoConfig struct {
+	CurrentKey string            `mapstructure:"current_key"` // 当前加密使用的密钥ID 为空表示不加密
+	Keys       map[string]string `mapstructure:"keys"`        // 密钥列表 键为密钥ID(小写) 值为Base64编码的32字节密钥
+}
+
+// SurveyCacheConfig 问卷缓存配置 用于拦截不存在的问卷访问路径 避免请求穿透至数据库
+type SurveyCacheConfig struct {
+	NegativeTTL time.Duration `mapstructure:"negative_ttl"` // 不存在的访问路径缓存时长 为0表示不缓存
+	PathFilter  bool          `mapstructure:"path_filter"`  // 是否启用访问路径布隆过滤器 启动时按全部问卷重建
+}
+
+// MetricsConfig 监控指标配置
+type MetricsConfig struct {
+	Enable bool `mapstructure:"enable"` // 是否暴露/metrics接口并统计HTTP请求耗时 指标可能包含问卷ID等信息 请勿对公网开放
+}
+
+// TracingConfig 链路追踪配置 通过OTLP HTTP协议上报至Collector
+type TracingConfig struct {
+	Enable      bool    `mapstructure:"enable"`       // 是否启用链路追踪
+	Endpoint    string  `mapstructure:"endpoint"`     // Collector地址 host:port
+	Insecure    bool    `mapstructure:"insecure"`     // 是否使用HTTP上报 否则使用HTTPS
+	SampleRatio float64 `mapstructure:"sample_ratio"` // 采样率 0~1 上游请求已采样时跟随上游
+}
+
+// DBReplicaConfig 数据库读写分离配置 仅管理端答卷列表/统计/导出及问卷列表等重查询读从库
+type DBReplicaConfig struct {
+	Scopes    []string      `mapstructure:"scopes"`     // 从库配置名 格式与db配置相同 为空表示不启用读写分离
+	PinWindow time.Duration `mapstructure:"pin_window"` // 管理员写操作后读主库的时长 应大于从库复制延迟
+}
+
+// Enable 是否启用读写分离
+func (c DBReplicaConfig) Enable() bool {
+	return len(c.Scopes) > 0
+}
+
+// ArchiveConfig 答卷归档配置 问卷结束超过指定天数后答卷移入归档表 统计数据保留 管理端查询及导出不受影响
+type ArchiveConfig struct {
+	AfterDays int `mapstructure:"after_days"` // 问卷结束后归档的天数 为0表示不自动归档 可使用archive命令手动归档
 }
@@ -14,11 +14,19 @@ const (
 	AdminTypeSuper  AdminType = 2 // 超级管理员
 )
 
+type AdminStatus int8
+
+const (
+	AdminStatusNormal   AdminStatus = 1 // 正常
+	AdminStatusDisabled AdminStatus = 2 // 禁用
+)
+
 type SurveyType int8
 
 const (
 	SurveyTypeSurvey SurveyType = 1 // 问卷
 	SurveyTypeVote   SurveyType = 2 // 投票
+	SurveyTypeQuiz   SurveyType = 3 // 测验
 )
 
 type SurveyStatus int8
@@ -28,6 +36,28 @@ const (
 	SurveyStatusPublished   SurveyStatus = 2 // 已发布
 )
 
+type AccessMode string
+
+const (
+	AccessModePassword AccessMode = "password" // 统一访问密码
+	AccessModeCode     AccessMode = "code"     // 一次性访问码
+)
+
+type RetentionAction string
+
+const (
+	RetentionActionPurge     RetentionAction = "purge"     // 删除答卷
+	RetentionActionAnonymize RetentionAction = "anonymize" // 匿名化答卷
+)
+
+type SubmitStatus string
+
+const (
+	SubmitStatusPending SubmitStatus = "pending" // 排队中
+	SubmitStatusDone    SubmitStatus = "done"    // 已入库
+	SubmitStatusFailed  SubmitStatus = "failed"  // 入库失败
+)
+
 type QuestionType string
 
 const (
@@ -38,4 +68,60 @@ const (
 	QuestionTypeVoteRadio    QuestionType = "vote-radio"    // 投票-单选
 	QuestionTypeVoteCheckbox QuestionType = "vote-checkbox" // 投票-多选
 	QuestionTypeUpload       QuestionType = "upload"        // 上传
+	QuestionTypeCascade      QuestionType = "cascade"       // 级联选择
+)
+
+type BankScope int8
+
+const (
+	BankScopePrivate BankScope = 1 // 私有
+	BankScopeShared  BankScope = 2 // 共享
+)
+
+type AuditAction string
+
+const (
+	AuditActionSurveyCreate AuditAction = "survey.create"    // 创建问卷
+	AuditActionSurveyUpdate AuditAction = "survey.update"    // 更新问卷
+	AuditActionSurveyStatus AuditAction = "survey.status"    // 修改问卷状态
+	AuditActionSurveyDelete AuditAction = "survey.delete"    // 删除问卷
+	AuditActionSurveyFreeze AuditAction = "survey.freeze"    // 暂停或恢复问卷提交
+	AuditActionResultExport AuditAction = "result.export"    // 导出答卷
+	AuditActionResultReveal AuditAction = "result.reveal"    // 查看答卷敏感信息明文
+	AuditActionRetention    AuditAction = "result.retention" // 按保留策略处理答卷 由定时任务执行 管理员ID为0
+	AuditActionArchive      AuditAction = "result.archive"   // 归档答卷 由定时任务或命令执行 管理员ID为0
+	AuditActionRestore      AuditAction = "result.restore"   // 恢复已归档答卷 由命令执行 管理员ID为0
+
+	AuditActionPrivacyExport AuditAction = "privacy.export" // 导出用户全部答卷
+	AuditActionPrivacyErase  AuditAction = "privacy.erase"  // 删除用户全部答卷
+
+	AuditActionAccessCodeCreate AuditAction = "access_code.create" // 生成问卷访问码
+	AuditActionAccessCodeExport AuditAction = "access_code.export" // 导出问卷访问码
+	AuditActionAllowlistSave    AuditAction = "allowlist.save"     // 保存问卷答题名单
+
+	AuditActionAdminStatus   AuditAction = "admin.status"   // 修改管理员状态
+	AuditActionAdminPassword AuditAction = "admin.password" // 重置管理员密码
+	AuditActionAdminDelete   AuditAction = "admin.delete"   // 删除管理员并转移问卷
+	AuditActionSessionRevoke AuditAction = "session.revoke" // 吊销管理员或用户全部会话
+	AuditActionInviteCreate  AuditAction = "invite.create"  // 生成邀请码
+	AuditActionInviteExpire  AuditAction = "invite.expire"  // 作废邀请码
+
+	AuditActionMaintenance AuditAction = "system.maintenance"  // 开启或关闭维护模式 由命令执行时管理员ID为0
+	AuditActionTotpEnforce AuditAction = "system.totp_enforce" // 设置强制二次验证
+)
+
+type AuditTarget string
+
+const (
+	AuditTargetSurvey AuditTarget = "survey" // 问卷
+	AuditTargetUser   AuditTarget = "user"   // 用户 对象ID为0 用户名见操作详情
+	AuditTargetSystem AuditTarget = "system" // 系统 对象ID为0
+	AuditTargetAdmin  AuditTarget = "admin"  // 管理员
+	AuditTargetInvite AuditTarget = "invite" // 邀请码
+)
+
+type SettingName string
+
+const (
+	SettingTotpEnforced SettingName = "totp_enforced" // 是否强制管理员启用二次验证 true|false
 )
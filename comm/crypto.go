@@ -0,0 +1,137 @@
+package comm
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// encryptedPrefix 加密值前缀 格式为enc:<密钥ID>:<Base64(nonce+密文)>
+const encryptedPrefix = "enc:"
+
+// AnswerKeyring 敏感数据加密密钥环 用于敏感答案及管理员TOTP密钥 由BizConfBoot初始化
+var AnswerKeyring *Keyring
+
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// Keyring 基于AES-256-GCM的加密密钥环 使用当前密钥加密 按密文中的密钥ID解密 以支持密钥轮换
+type Keyring struct {
+	current string
+	aeads   map[string]cipher.AEAD
+}
+
+// NewKeyring 按配置创建密钥环 未配置当前密钥时返回的密钥环不加密
+func NewKeyring(conf CryptoConfig) (*Keyring, error) {
+	k := &Keyring{
+		current: conf.CurrentKey,
+		aeads:   make(map[string]cipher.AEAD, len(conf.Keys)),
+	}
+	for id, encoded := range conf.Keys {
+		if strings.Contains(id, ":") {
+			return nil, fmt.Errorf("密钥ID[%s]不能包含冒号", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("密钥[%s]Base64解码失败: %w", id, err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("密钥[%s]长度须为32字节", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		k.aeads[id] = aead
+	}
+	if k.current != "" && k.aeads[k.current] == nil {
+		return nil, fmt.Errorf("当前密钥[%s]未配置", k.current)
+	}
+	return k, nil
+}
+
+// Enabled 是否已配置当前加密密钥
+func (k *Keyring) Enabled() bool {
+	return k != nil && k.current != ""
+}
+
+// Encrypt 使用当前密钥加密 未配置密钥时原样返回
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	if !k.Enabled() {
+		return plaintext, nil
+	}
+	aead := k.aeads[k.current]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + k.current + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 解密加密值 非加密值原样返回
+func (k *Keyring) Decrypt(value string) (string, error) {
+	id, payload, ok := splitEncrypted(value)
+	if !ok {
+		return value, nil
+	}
+	if k == nil || k.aeads[id] == nil {
+		return "", fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+	aead := k.aeads[id]
+	sealed, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("ciphertext too short")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// NeedRekey 判断值是否需要使用当前密钥重新加密 包括明文及由旧密钥加密的值
+func (k *Keyring) NeedRekey(value string) bool {
+	if !k.Enabled() || value == "" {
+		return false
+	}
+	id, _, ok := splitEncrypted(value)
+	return !ok || id != k.current
+}
+
+// IsEncrypted 判断值是否为加密值
+func IsEncrypted(value string) bool {
+	_, _, ok := splitEncrypted(value)
+	return ok
+}
+
+func splitEncrypted(value string) (id, payload string, ok bool) {
+	rest, ok := strings.CutPrefix(value, encryptedPrefix)
+	if !ok {
+		return "", "", false
+	}
+	return strings.Cut(rest, ":")
+}
+
+// MaskSensitive 脱敏显示 保留首尾少量字符 如手机号138****5678
+func MaskSensitive(value string) string {
+	runes := []rune(value)
+	head, tail := 1, 1
+	switch {
+	case len(runes) >= 11:
+		head, tail = 3, 4
+	case len(runes) <= 2:
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:head]) + strings.Repeat("*", len(runes)-head-tail) + string(runes[len(runes)-tail:])
+}
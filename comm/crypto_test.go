@@ -0,0 +1,264 @@
+package comm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func testKeyring(t *testing.T, current string, ids ...string) *Keyring {
+	t.Helper()
+	keys := make(map[string]string, len(ids))
+	for i, id := range ids {
+		keys[id] = testKey(byte(i + 1))
+	}
+	k, err := NewKeyring(CryptoConfig{CurrentKey: current, Keys: keys})
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	return k
+}
+
+func TestNewKeyring(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    CryptoConfig
+		wantErr bool
+	}{
+		{name: "未配置密钥", conf: CryptoConfig{}},
+		{name: "正常配置", conf: CryptoConfig{CurrentKey: "k1", Keys: map[string]string{"k1": testKey(1)}}},
+		{name: "密钥ID包含冒号", conf: CryptoConfig{Keys: map[string]string{"k:1": testKey(1)}}, wantErr: true},
+		{name: "Base64解码失败", conf: CryptoConfig{Keys: map[string]string{"k1": "!!!"}}, wantErr: true},
+		{name: "密钥长度错误", conf: CryptoConfig{Keys: map[string]string{"k1": base64.StdEncoding.EncodeToString([]byte("short"))}}, wantErr: true},
+		{name: "当前密钥未配置", conf: CryptoConfig{CurrentKey: "k2", Keys: map[string]string{"k1": testKey(1)}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyring(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewKeyring() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyringRoundTrip(t *testing.T) {
+	k := testKeyring(t, "k1", "k1")
+	for _, plaintext := range []string{"", "13812345678", "110101199001011234", "中文答案", strings.Repeat("a", 4096)} {
+		encrypted, err := k.Encrypt(plaintext)
+		if err != nil {
+			t.Fatalf("Encrypt(%q) error = %v", plaintext, err)
+		}
+		if !IsEncrypted(encrypted) || !strings.HasPrefix(encrypted, "enc:k1:") {
+			t.Errorf("Encrypt(%q) = %q, want enc:k1: prefix", plaintext, encrypted)
+		}
+		if plaintext != "" && strings.Contains(encrypted, plaintext) {
+			t.Errorf("Encrypt(%q) leaks plaintext", plaintext)
+		}
+		got, err := k.Decrypt(encrypted)
+		if err != nil {
+			t.Fatalf("Decrypt() error = %v", err)
+		}
+		if got != plaintext {
+			t.Errorf("Decrypt(Encrypt(%q)) = %q", plaintext, got)
+		}
+	}
+}
+
+func TestKeyringDisabled(t *testing.T) {
+	k := testKeyring(t, "", "k1")
+	got, err := k.Encrypt("13812345678")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if got != "13812345678" {
+		t.Errorf("Encrypt() without current key = %q, want plaintext", got)
+	}
+
+	// 未配置当前密钥时仍可使用已配置的密钥解密
+	encrypted, err := testKeyring(t, "k1", "k1").Encrypt("secret")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if got, err := k.Decrypt(encrypted); err != nil || got != "secret" {
+		t.Errorf("Decrypt() = %q, %v, want secret", got, err)
+	}
+}
+
+func TestKeyringRotation(t *testing.T) {
+	oldKeyring := testKeyring(t, "k1", "k1")
+	encrypted, err := oldKeyring.Encrypt("13812345678")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	// 轮换后保留旧密钥 可解密旧密文
+	rotated := testKeyring(t, "k2", "k1", "k2")
+	got, err := rotated.Decrypt(encrypted)
+	if err != nil {
+		t.Fatalf("Decrypt() old ciphertext error = %v", err)
+	}
+	if got != "13812345678" {
+		t.Errorf("Decrypt() old ciphertext = %q", got)
+	}
+	if !rotated.NeedRekey(encrypted) {
+		t.Error("NeedRekey() old ciphertext = false, want true")
+	}
+
+	reencrypted, err := rotated.Encrypt(got)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if !strings.HasPrefix(reencrypted, "enc:k2:") {
+		t.Errorf("Encrypt() after rotation = %q, want enc:k2: prefix", reencrypted)
+	}
+	if rotated.NeedRekey(reencrypted) {
+		t.Error("NeedRekey() current ciphertext = true, want false")
+	}
+}
+
+func TestKeyringNeedRekey(t *testing.T) {
+	k := testKeyring(t, "k2", "k1", "k2")
+	oldCiphertext, err := testKeyring(t, "k1", "k1").Encrypt("a")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	currentCiphertext, err := k.Encrypt("a")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		keyring *Keyring
+		value   string
+		want    bool
+	}{
+		{name: "明文", keyring: k, value: "13812345678", want: true},
+		{name: "空值", keyring: k, value: "", want: false},
+		{name: "旧密钥密文", keyring: k, value: oldCiphertext, want: true},
+		{name: "当前密钥密文", keyring: k, value: currentCiphertext, want: false},
+		{name: "未配置当前密钥", keyring: testKeyring(t, "", "k1"), value: "13812345678", want: false},
+		{name: "未初始化密钥环", keyring: nil, value: "13812345678", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.keyring.NeedRekey(tt.value); got != tt.want {
+				t.Errorf("NeedRekey(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyringDecrypt(t *testing.T) {
+	k := testKeyring(t, "k1", "k1")
+	encrypted, err := k.Encrypt("secret")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	unknown, err := testKeyring(t, "k9", "k9").Encrypt("secret")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	tampered := encrypted[:len(encrypted)-2] + flipBase64(encrypted[len(encrypted)-2:])
+
+	tests := []struct {
+		name    string
+		keyring *Keyring
+		value   string
+		want    string
+		wantErr error
+		anyErr  bool
+	}{
+		{name: "明文原样返回", keyring: k, value: "plain", want: "plain"},
+		{name: "未初始化密钥环 明文原样返回", keyring: nil, value: "plain", want: "plain"},
+		{name: "正常解密", keyring: k, value: encrypted, want: "secret"},
+		{name: "未知密钥", keyring: k, value: unknown, wantErr: ErrUnknownKey},
+		{name: "未初始化密钥环 密文", keyring: nil, value: encrypted, wantErr: ErrUnknownKey},
+		{name: "密文被篡改", keyring: k, value: tampered, anyErr: true},
+		{name: "密文过短", keyring: k, value: "enc:k1:AAAA", anyErr: true},
+		{name: "Base64格式错误", keyring: k, value: "enc:k1:!!!", anyErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.keyring.Decrypt(tt.value)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Decrypt() error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.anyErr:
+				if err == nil {
+					t.Errorf("Decrypt() = %q, want error", got)
+				}
+			default:
+				if err != nil || got != tt.want {
+					t.Errorf("Decrypt() = %q, %v, want %q", got, err, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// flipBase64 替换Base64字符 用于构造被篡改的密文
+func flipBase64(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c == 'A' {
+			b[i] = 'B'
+		} else {
+			b[i] = 'A'
+		}
+	}
+	return string(b)
+}
+
+func TestIsEncrypted(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "enc:k1:AAAA", want: true},
+		{value: "enc:k1", want: false},
+		{value: "enc", want: false},
+		{value: "13812345678", want: false},
+		{value: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsEncrypted(tt.value); got != tt.want {
+			t.Errorf("IsEncrypted(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMaskSensitive(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: ""},
+		{value: "a", want: "*"},
+		{value: "ab", want: "**"},
+		{value: "abc", want: "a*c"},
+		{value: "张三丰", want: "张*丰"},
+		{value: "1234567890", want: "1********0"},
+		{value: "13812345678", want: "138****5678"},
+		{value: "110101199001011234", want: "110***********1234"},
+	}
+
+	for _, tt := range tests {
+		if got := MaskSensitive(tt.value); got != tt.want {
+			t.Errorf("MaskSensitive(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -1,14 +1,46 @@
 package comm
 
+import "strconv"
+
+// TokenSession 访问Token会话信息 用于Token吊销
+type TokenSession struct {
+	TokenID  string `json:"token_id" desc:"Token ID"`
+	IssuedAt int64  `json:"issued_at" desc:"签发时间 毫秒时间戳"`
+}
+
+func (s TokenSession) Session() TokenSession {
+	return s
+}
+
+// SessionIdentity 支持会话吊销的登录身份
+type SessionIdentity interface {
+	Subject() string // 会话主体 吊销全部会话时使用
+	Session() TokenSession
+}
+
 type UserIdentity struct {
 	Username string   `json:"username" desc:"用户名"`
 	Type     UserType `json:"type" desc:"用户类型"`
+
+	TokenSession
+}
+
+func (u UserIdentity) Subject() string {
+	return u.Username
 }
 
 type AdminIdentity struct {
 	ID       int64     `json:"id" desc:"ID"`
 	Username string    `json:"username" desc:"用户名"`
 	Type     AdminType `json:"type" desc:"用户类型"`
+
+	TwoFactor bool `json:"two_factor,omitempty" desc:"本次会话是否已通过二次验证"`
+
+	TokenSession
+}
+
+func (a AdminIdentity) Subject() string {
+	return strconv.FormatInt(a.ID, 10)
 }
 
 type ResultItem struct {
@@ -0,0 +1,67 @@
+package comm
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/zjutjh/mygo/kit"
+)
+
+const MetricsNamespace = "survey"
+
+// Prometheus监控指标 通过/metrics暴露 biz.metrics.enable=true时生效
+var (
+	MetricHTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: MetricsNamespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP请求耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	MetricSubmitTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "submit_total",
+		Help:      "问卷提交次数 按问卷及结果码统计",
+	}, []string{"survey_id", "code"})
+
+	MetricSurveyCacheTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "survey_cache_total",
+		Help:      "问卷缓存查询次数 result: local_hit/redis_hit/negative_hit/miss/filtered",
+	}, []string{"result"})
+
+	MetricSingleflightTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "singleflight_total",
+		Help:      "问卷回源数据库次数 shared=true表示与并发请求合并",
+	}, []string{"shared"})
+
+	MetricDBTransactionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: MetricsNamespace,
+		Name:      "db_transaction_duration_seconds",
+		Help:      "数据库事务耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+
+	MetricRedisErrorTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "redis_error_total",
+		Help:      "Redis命令错误次数 不含键不存在",
+	}, []string{"command"})
+)
+
+// ObserveSubmit 记录问卷提交结果
+func ObserveSubmit(surveyID int64, code kit.Code) {
+	MetricSubmitTotal.WithLabelValues(strconv.FormatInt(surveyID, 10), strconv.FormatInt(code.Code, 10)).Inc()
+}
+
+// ObserveTransaction 记录数据库事务耗时
+func ObserveTransaction(start time.Time, err error) {
+	result := "commit"
+	if err != nil {
+		result = "rollback"
+	}
+	MetricDBTransactionDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,95 @@
+package comm
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP参数 RFC 6238 默认参数 兼容主流验证器应用
+const (
+	TotpPeriod = 30 // 时间步长 单位秒
+	TotpDigits = 6  // 验证码位数
+	TotpSkew   = 1  // 允许前后偏差的时间步长数
+
+	RecoveryCodeCount = 10 // 恢复码数量
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTotpSecret 生成Base32编码的TOTP密钥
+func GenerateTotpSecret() string {
+	key := make([]byte, 20)
+	_, _ = rand.Read(key)
+	return totpEncoding.EncodeToString(key)
+}
+
+// TotpURI 生成验证器应用扫码使用的otpauth URI
+func TotpURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TotpDigits))
+	query.Set("period", fmt.Sprint(TotpPeriod))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// VerifyTotp 校验TOTP验证码 返回匹配的时间步长 用于防止同一验证码重复使用
+func VerifyTotp(secret, code string, now time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != TotpDigits {
+		return 0, false
+	}
+	step := now.Unix() / TotpPeriod
+	for i := -TotpSkew; i <= TotpSkew; i++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step+int64(i))), []byte(code)) == 1 {
+			return step + int64(i), true
+		}
+	}
+	return 0, false
+}
+
+// totpCode 计算指定时间步长的验证码
+func totpCode(key []byte, step int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for range TotpDigits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", TotpDigits, value%mod)
+}
+
+// GenerateRecoveryCodes 生成二次验证恢复码 格式为xxxxx-xxxxx
+func GenerateRecoveryCodes() []string {
+	codes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		text := strings.ToLower(rand.Text()[:10])
+		codes[i] = text[:5] + "-" + text[5:]
+	}
+	return codes
+}
+
+// HashRecoveryCode 计算恢复码哈希 忽略大小写及分隔符
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
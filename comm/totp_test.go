@@ -0,0 +1,103 @@
+package comm
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Key RFC 6238 附录B SHA1测试密钥
+var rfc6238Key = []byte("12345678901234567890")
+
+func TestTotpCodeRFC6238(t *testing.T) {
+	// RFC 6238 附录B SHA1测试向量 原向量为8位 取末6位
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	for _, tt := range tests {
+		if got := totpCode(rfc6238Key, tt.unix/TotpPeriod); got != tt.want {
+			t.Errorf("totpCode(T=%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestVerifyTotp(t *testing.T) {
+	secret := totpEncoding.EncodeToString(rfc6238Key)
+	now := time.Unix(1111111111, 0)
+	step := now.Unix() / TotpPeriod
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "当前时间步长", secret: secret, code: totpCode(rfc6238Key, step), wantStep: step, wantOK: true},
+		{name: "前一时间步长", secret: secret, code: totpCode(rfc6238Key, step-1), wantStep: step - 1, wantOK: true},
+		{name: "后一时间步长", secret: secret, code: totpCode(rfc6238Key, step+1), wantStep: step + 1, wantOK: true},
+		{name: "超出允许偏差 前两个时间步长", secret: secret, code: totpCode(rfc6238Key, step-2)},
+		{name: "超出允许偏差 后两个时间步长", secret: secret, code: totpCode(rfc6238Key, step+2)},
+		{name: "密钥小写", secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", code: "050471", wantStep: step, wantOK: true},
+		{name: "验证码错误", secret: secret, code: "000000"},
+		{name: "验证码位数错误", secret: secret, code: "50471"},
+		{name: "密钥格式错误", secret: "not-base32!", code: "050471"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, ok := VerifyTotp(tt.secret, tt.code, now)
+			if ok != tt.wantOK || gotStep != tt.wantStep {
+				t.Errorf("VerifyTotp(%q) = %d, %v, want %d, %v", tt.code, gotStep, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateTotpSecret(t *testing.T) {
+	secret := GenerateTotpSecret()
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("GenerateTotpSecret() = %q, decode error = %v", secret, err)
+	}
+	if len(key) != 20 {
+		t.Errorf("GenerateTotpSecret() key length = %d, want 20", len(key))
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	want := HashRecoveryCode("abcde-fghij")
+	for _, code := range []string{"abcde-fghij", "ABCDE-FGHIJ", "abcdefghij", "  abcde-fghij\n", "ab-cde-fg-hij"} {
+		if got := HashRecoveryCode(code); got != want {
+			t.Errorf("HashRecoveryCode(%q) = %s, want %s", code, got, want)
+		}
+	}
+	if HashRecoveryCode("abcde-fghik") == want {
+		t.Error("HashRecoveryCode() different codes share a hash")
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes := GenerateRecoveryCodes()
+	if len(codes) != RecoveryCodeCount {
+		t.Fatalf("GenerateRecoveryCodes() returned %d codes, want %d", len(codes), RecoveryCodeCount)
+	}
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("recovery code %q, want xxxxx-xxxxx", code)
+		}
+		if seen[code] {
+			t.Errorf("duplicate recovery code %q", code)
+		}
+		seen[code] = true
+	}
+}
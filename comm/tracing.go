@@ -0,0 +1,26 @@
+package comm
+
+import (
+	"context"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const TracerName = "app"
+
+// Tracer 返回应用Tracer 未启用链路追踪时创建的Span不会上报
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// SpanParent 返回用于创建子Span的上下文及请求ID
+// gin.Context未开启ContextWithFallback 需从其Request中获取HTTP请求的Span
+func SpanParent(ctx context.Context) (context.Context, string) {
+	if c, ok := ctx.(*gin.Context); ok && c.Request != nil {
+		return c.Request.Context(), requestid.Get(c)
+	}
+	return ctx, ""
+}
@@ -1,6 +1,18 @@
 package comm
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyPasswordHash 账号不存在时用于比对的密码哈希 使响应耗时与密码错误一致 避免枚举用户名
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("dummy"), bcrypt.DefaultCost)
+	return hashedPassword
+})
 
 func HashPassword(password string) (string, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -13,3 +25,22 @@ func HashPassword(password string) (string, error) {
 func ComparePassword(hashedPassword, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
+
+// CompareDummyPassword 执行一次必定失败的密码比对 用于账号不存在时消除耗时差异
+func CompareDummyPassword(password string) {
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+}
+
+// GenerateAccessCodes 生成问卷一次性访问码 由大写字母及数字2-7组成 便于手动输入
+func GenerateAccessCodes(n int) []string {
+	codes := make([]string, n)
+	for i := range codes {
+		codes[i] = rand.Text()[:10]
+	}
+	return codes
+}
+
+// NormalizeAccessCode 规范化用户输入的访问码 忽略首尾空白及大小写
+func NormalizeAccessCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,134 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/model"
+	"app/dao/repo"
+	"app/schema"
+)
+
+// ArchiveJob 答卷归档任务 问卷结束超过归档天数后将答卷移入归档表
+type ArchiveJob struct{}
+
+func (ArchiveJob) Run() {
+	days := comm.BizConf.Archive.AfterDays
+	if days <= 0 {
+		return
+	}
+	ctx := context.Background()
+
+	// 获取任务锁
+	locker := cache.NewJobLockCache()
+	token, err := locker.Lock(ctx, "archive", time.Hour)
+	if err != nil {
+		nlog.Pick().WithError(err).Error("获取答卷归档任务锁失败")
+		return
+	}
+	if token == "" {
+		return
+	}
+	defer func() {
+		if err := locker.Unlock(ctx, "archive", token); err != nil {
+			nlog.Pick().WithError(err).Error("释放答卷归档任务锁失败")
+		}
+	}()
+
+	now := time.Now()
+	err = repo.NewSurveyRepo().FindAllInBatches(ctx, 100, func(list []*model.Survey) error {
+		for _, survey := range list {
+			// 恢复中断的问卷继续恢复 避免长期合并查询两表且暂停提交
+			if survey.ArchivedAt > 0 && survey.ArchivingAt > 0 {
+				if err := RestoreSurvey(ctx, survey); err != nil {
+					nlog.Pick().WithError(err).Errorf("继续恢复问卷答卷失败 ID:%d", survey.ID)
+				}
+				continue
+			}
+			if survey.ArchivedAt > 0 {
+				continue
+			}
+			var surveySchema schema.SurveySchema
+			if err := sonic.UnmarshalString(survey.Schema, &surveySchema); err != nil {
+				nlog.Pick().WithError(err).Errorf("问卷结构反序列化失败 ID:%d", survey.ID)
+				continue
+			}
+			if !surveySchema.BaseConf.ArchiveDue(now, days) {
+				continue
+			}
+			if err := ArchiveSurvey(ctx, survey, now); err != nil {
+				nlog.Pick().WithError(err).Errorf("归档问卷答卷失败 ID:%d", survey.ID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		nlog.Pick().WithError(err).Error("遍历问卷失败")
+	}
+}
+
+// ArchiveSurvey 标记问卷归档中 -> 分批归档问卷答卷 -> 删除问卷缓存 -> 记录审计日志
+func ArchiveSurvey(ctx context.Context, survey *model.Survey, now time.Time) error {
+	if err := startArchiving(ctx, survey, now); err != nil {
+		return err
+	}
+	count, err := repo.ArchiveSurveyResults(ctx, survey.ID, now)
+	if err != nil {
+		return err
+	}
+	afterArchiveChange(ctx, survey, comm.AuditActionArchive, count)
+	return nil
+}
+
+// RestoreSurvey 标记问卷恢复中 -> 分批恢复已归档问卷答卷 -> 删除问卷缓存 -> 记录审计日志
+func RestoreSurvey(ctx context.Context, survey *model.Survey) error {
+	if err := startArchiving(ctx, survey, time.Now()); err != nil {
+		return err
+	}
+	count, err := repo.RestoreSurveyResults(ctx, survey.ID)
+	if err != nil {
+		return err
+	}
+	afterArchiveChange(ctx, survey, comm.AuditActionRestore, count)
+	return nil
+}
+
+// startArchiving 标记问卷答卷归档或恢复开始 并删除问卷缓存 移动期间暂停提交 查询答卷时合并两表
+// 上次移动中断时保留原标记时间
+func startArchiving(ctx context.Context, survey *model.Survey, now time.Time) error {
+	if survey.ArchivingAt > 0 {
+		return nil
+	}
+	if _, err := repo.NewSurveyRepo().UpdateArchivingAt(ctx, survey.ID, now.UnixMilli()); err != nil {
+		return err
+	}
+	if err := cache.NewSurveyCache().Del(ctx, survey.Path); err != nil {
+		nlog.Pick().WithError(err).Errorf("删除问卷缓存失败 ID:%d", survey.ID)
+	}
+	return nil
+}
+
+// afterArchiveChange 删除问卷缓存使归档状态生效 并记录审计日志
+func afterArchiveChange(ctx context.Context, survey *model.Survey, action comm.AuditAction, count int) {
+	if err := cache.NewSurveyCache().Del(ctx, survey.Path); err != nil {
+		nlog.Pick().WithError(err).Errorf("删除问卷缓存失败 ID:%d", survey.ID)
+	}
+
+	err := repo.NewAuditLogRepo().Record(ctx, repo.AuditEntry{
+		Action:     action,
+		TargetType: comm.AuditTargetSurvey,
+		TargetID:   survey.ID,
+		Detail: map[string]any{
+			"title": survey.Title,
+			"count": count,
+		},
+	})
+	if err != nil {
+		nlog.Pick().WithError(err).Error("记录审计日志失败")
+	}
+}
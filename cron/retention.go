@@ -0,0 +1,131 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/model"
+	"app/dao/repo"
+	"app/schema"
+)
+
+const retentionBatchSize = 500
+
+// RetentionJob 答卷保留策略任务 问卷结束超过保留天数后按配置删除或匿名化答卷
+type RetentionJob struct{}
+
+func (RetentionJob) Run() {
+	ctx := context.Background()
+
+	// 获取任务锁
+	locker := cache.NewJobLockCache()
+	token, err := locker.Lock(ctx, "retention", time.Hour)
+	if err != nil {
+		nlog.Pick().WithError(err).Error("获取答卷保留策略任务锁失败")
+		return
+	}
+	if token == "" {
+		return
+	}
+	defer func() {
+		if err := locker.Unlock(ctx, "retention", token); err != nil {
+			nlog.Pick().WithError(err).Error("释放答卷保留策略任务锁失败")
+		}
+	}()
+
+	now := time.Now()
+	err = repo.NewSurveyRepo().FindAllInBatches(ctx, 100, func(list []*model.Survey) error {
+		for _, survey := range list {
+			// 答卷归档或恢复中的问卷待移动完成后处理
+			if survey.ArchivingAt > 0 {
+				continue
+			}
+			var surveySchema schema.SurveySchema
+			if err := sonic.UnmarshalString(survey.Schema, &surveySchema); err != nil {
+				nlog.Pick().WithError(err).Errorf("问卷结构反序列化失败 ID:%d", survey.ID)
+				continue
+			}
+			if !surveySchema.BaseConf.RetentionDue(now) {
+				continue
+			}
+
+			count, err := applyRetention(ctx, survey, &surveySchema, now)
+			if err != nil {
+				nlog.Pick().WithError(err).Errorf("按保留策略处理答卷失败 ID:%d", survey.ID)
+			}
+			if count == 0 {
+				continue
+			}
+
+			// 记录审计日志
+			err = repo.NewAuditLogRepo().Record(ctx, repo.AuditEntry{
+				Action:     comm.AuditActionRetention,
+				TargetType: comm.AuditTargetSurvey,
+				TargetID:   survey.ID,
+				Detail: map[string]any{
+					"title":          survey.Title,
+					"retention_days": surveySchema.BaseConf.RetentionDays,
+					"action":         surveySchema.BaseConf.RetentionAction,
+					"count":          count,
+				},
+			})
+			if err != nil {
+				nlog.Pick().WithError(err).Error("记录审计日志失败")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		nlog.Pick().WithError(err).Error("遍历问卷失败")
+	}
+}
+
+// applyRetention 分批删除或匿名化问卷答卷 答卷已归档时处理归档表 返回处理的答卷数量
+func applyRetention(ctx context.Context, survey *model.Survey, surveySchema *schema.SurveySchema, now time.Time) (int, error) {
+	surveyID := survey.ID
+	resultRepo := repo.NewResultRepo().ForSurvey(survey)
+	count := 0
+
+	switch surveySchema.BaseConf.RetentionAction {
+	case comm.RetentionActionPurge:
+		for {
+			ids, err := resultRepo.FindIDsBySurveyID(ctx, surveyID, retentionBatchSize)
+			if err != nil || len(ids) == 0 {
+				return count, err
+			}
+			if _, err := resultRepo.DeleteByIDs(ctx, ids); err != nil {
+				return count, err
+			}
+			count += len(ids)
+		}
+	case comm.RetentionActionAnonymize:
+		for {
+			list, err := resultRepo.FindUnanonymized(ctx, surveyID, retentionBatchSize)
+			if err != nil || len(list) == 0 {
+				return count, err
+			}
+			for _, res := range list {
+				// 答卷数据无法解析时清空答卷内容
+				var items []comm.ResultItem
+				if err := sonic.UnmarshalString(res.Data, &items); err != nil {
+					nlog.Pick().WithError(err).Warnf("答卷数据解析失败 匿名化时将清空答卷内容 ID:%d", res.ID)
+				}
+				data, err := sonic.MarshalString(surveySchema.StripSensitive(items))
+				if err != nil {
+					return count, err
+				}
+				if err := resultRepo.Anonymize(ctx, res.ID, data, now.UnixMilli()); err != nil {
+					return count, err
+				}
+				count++
+			}
+		}
+	}
+
+	return count, nil
+}
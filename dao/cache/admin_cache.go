@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zjutjh/mygo/nedis"
+
+	"app/comm"
+)
+
+const (
+	AdminStatusCachePrefix = "admin:status:"
+	AdminStatusCacheTTL    = 10 * time.Minute
+)
+
+// AdminStatusCache 管理员账号状态缓存 状态为0表示管理员不存在
+type AdminStatusCache struct {
+	rdb redis.UniversalClient
+}
+
+func NewAdminStatusCache() *AdminStatusCache {
+	return &AdminStatusCache{
+		rdb: nedis.Pick(),
+	}
+}
+
+func (c *AdminStatusCache) Set(ctx context.Context, id int64, status comm.AdminStatus) error {
+	return c.rdb.Set(ctx, c.getKey(id), int8(status), AdminStatusCacheTTL).Err()
+}
+
+// Get 获取管理员账号状态 缓存未命中时返回nil
+func (c *AdminStatusCache) Get(ctx context.Context, id int64) (*comm.AdminStatus, error) {
+	val, err := c.rdb.Get(ctx, c.getKey(id)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	status, err := strconv.ParseInt(val, 10, 8)
+	if err != nil {
+		return nil, err
+	}
+	s := comm.AdminStatus(status)
+	return &s, nil
+}
+
+func (c *AdminStatusCache) Del(ctx context.Context, id int64) error {
+	return c.rdb.Del(ctx, c.getKey(id)).Err()
+}
+
+func (c *AdminStatusCache) getKey(id int64) string {
+	return fmt.Sprintf("%s%d", AdminStatusCachePrefix, id)
+}
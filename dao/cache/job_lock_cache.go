@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zjutjh/mygo/nedis"
+)
+
+const JobLockCachePrefix = "job:lock:"
+
+// jobUnlockScript 仅当锁仍由当前持有者持有时删除 避免任务超出锁有效期后误删其他实例获取的锁
+var jobUnlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// JobLockCache 定时任务锁 多实例部署时保证同一任务同时仅有一个实例执行
+type JobLockCache struct {
+	rdb redis.UniversalClient
+}
+
+func NewJobLockCache() *JobLockCache {
+	return &JobLockCache{
+		rdb: nedis.Pick(),
+	}
+}
+
+// Lock 获取任务锁 ttl应大于任务最长执行时间 返回持有凭证 未获取到锁时返回空
+func (c *JobLockCache) Lock(ctx context.Context, name string, ttl time.Duration) (string, error) {
+	token := rand.Text()
+	ok, err := c.rdb.SetNX(ctx, JobLockCachePrefix+name, token, ttl).Result()
+	if err != nil || !ok {
+		return "", err
+	}
+	return token, nil
+}
+
+// Unlock 使用持有凭证释放任务锁 锁已过期或被其他实例持有时不做处理
+func (c *JobLockCache) Unlock(ctx context.Context, name, token string) error {
+	return jobUnlockScript.Run(ctx, c.rdb, []string{JobLockCachePrefix + name}, token).Err()
+}
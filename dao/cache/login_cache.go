@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zjutjh/mygo/nedis"
+
+	"app/comm"
+)
+
+const (
+	LoginGuardCachePrefix = "login:guard:"
+
+	LoginGuardScopeAdmin  = "admin"
+	LoginGuardScopeUser   = "user"
+	LoginGuardScopeSurvey = "survey" // 问卷访问密码及访问码 以问卷ID及IP组合代替用户名计数 避免单个问卷被他人锁定
+)
+
+// LoginGuardCache 登录防爆破缓存 按用户名及IP分别记录连续失败次数
+// 超出免延迟次数后按失败次数渐进延迟 达到上限后锁定一段时间
+type LoginGuardCache struct {
+	rdb   redis.UniversalClient
+	scope string
+	conf  comm.LoginGuardConfig
+}
+
+func NewLoginGuardCache(scope string) *LoginGuardCache {
+	return &LoginGuardCache{
+		rdb:   nedis.Pick(),
+		scope: scope,
+		conf:  comm.BizConf.LoginGuard,
+	}
+}
+
+// Wait 获取距离允许下次尝试登录的剩余时间 返回0表示允许尝试
+func (c *LoginGuardCache) Wait(ctx context.Context, username, ip string) (time.Duration, error) {
+	pipe := c.rdb.Pipeline()
+	cmds := []*redis.DurationCmd{
+		pipe.PTTL(ctx, c.getKey("lock", "user", username)),
+		pipe.PTTL(ctx, c.getKey("lock", "ip", ip)),
+		pipe.PTTL(ctx, c.getKey("delay", "user", username)),
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	// 键不存在或无过期时间时PTTL返回负值
+	var wait time.Duration
+	for _, cmd := range cmds {
+		wait = max(wait, cmd.Val())
+	}
+	return wait, nil
+}
+
+// Fail 记录一次登录失败 并按失败次数设置延迟或锁定
+func (c *LoginGuardCache) Fail(ctx context.Context, username, ip string) error {
+	userFailKey := c.getKey("fail", "user", username)
+	ipFailKey := c.getKey("fail", "ip", ip)
+
+	pipe := c.rdb.TxPipeline()
+	userFails := pipe.Incr(ctx, userFailKey)
+	pipe.Expire(ctx, userFailKey, c.conf.Window)
+	ipFails := pipe.Incr(ctx, ipFailKey)
+	pipe.Expire(ctx, ipFailKey, c.conf.Window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	pipe = c.rdb.TxPipeline()
+	if n := int(userFails.Val()); n >= c.conf.UserMaxFails {
+		pipe.Set(ctx, c.getKey("lock", "user", username), 1, c.conf.LockDuration)
+		pipe.Del(ctx, userFailKey, c.getKey("delay", "user", username))
+	} else if n > c.conf.FreeFails {
+		pipe.Set(ctx, c.getKey("delay", "user", username), 1, c.delay(n-c.conf.FreeFails))
+	}
+	if int(ipFails.Val()) >= c.conf.IPMaxFails {
+		pipe.Set(ctx, c.getKey("lock", "ip", ip), 1, c.conf.LockDuration)
+		pipe.Del(ctx, ipFailKey)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Reset 登录成功后清除用户名的失败记录 IP计数保留至自然过期
+func (c *LoginGuardCache) Reset(ctx context.Context, username string) error {
+	return c.rdb.Del(ctx, c.getKey("fail", "user", username), c.getKey("delay", "user", username)).Err()
+}
+
+// delay 计算第n次超出免延迟次数的失败后的延迟 每次翻倍 不超过上限
+func (c *LoginGuardCache) delay(n int) time.Duration {
+	d := time.Second << min(n-1, 30)
+	return min(d, c.conf.MaxDelay)
+}
+
+func (c *LoginGuardCache) getKey(kind, target, value string) string {
+	return fmt.Sprintf("%s%s:%s:%s:%s", LoginGuardCachePrefix, c.scope, kind, target, value)
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zjutjh/mygo/nedis"
+)
+
+const MaintenanceCacheKey = "system:maintenance"
+
+// MaintenanceCache 全局维护模式开关 值为开启时间(Unix毫秒) 不设置过期时间 须手动关闭
+type MaintenanceCache struct {
+	rdb redis.UniversalClient
+}
+
+func NewMaintenanceCache() *MaintenanceCache {
+	return &MaintenanceCache{
+		rdb: nedis.Pick(),
+	}
+}
+
+// Enable 开启维护模式 已开启时保留原开启时间
+func (c *MaintenanceCache) Enable(ctx context.Context, now time.Time) error {
+	return c.rdb.SetNX(ctx, MaintenanceCacheKey, now.UnixMilli(), 0).Err()
+}
+
+func (c *MaintenanceCache) Disable(ctx context.Context) error {
+	return c.rdb.Del(ctx, MaintenanceCacheKey).Err()
+}
+
+// Get 获取维护模式开启时间 未开启时返回nil
+func (c *MaintenanceCache) Get(ctx context.Context) (*time.Time, error) {
+	val, err := c.rdb.Get(ctx, MaintenanceCacheKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ms, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	since := time.UnixMilli(ms)
+	return &since, nil
+}
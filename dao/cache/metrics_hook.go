@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+
+	"app/comm"
+)
+
+// MetricsHook Redis命令监控 统计命令错误次数 键不存在不计为错误
+type MetricsHook struct{}
+
+func (MetricsHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (MetricsHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		observeRedisError(cmd.Name(), err)
+		return err
+	}
+}
+
+func (MetricsHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		for _, cmd := range cmds {
+			observeRedisError(cmd.Name(), cmd.Err())
+		}
+		return err
+	}
+}
+
+func observeRedisError(command string, err error) {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return
+	}
+	comm.MetricRedisErrorTotal.WithLabelValues(command).Inc()
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zjutjh/mygo/nedis"
+
+	"app/comm"
+)
+
+const PrimaryPinCachePrefix = "db:primary_pin:"
+
+// PrimaryPinCache 管理员写操作标记 有效期内该管理员的查询使用主库 避免从库复制延迟导致读不到刚写入的数据
+type PrimaryPinCache struct {
+	rdb redis.UniversalClient
+}
+
+func NewPrimaryPinCache() *PrimaryPinCache {
+	return &PrimaryPinCache{
+		rdb: nedis.Pick(),
+	}
+}
+
+// Pin 标记管理员刚完成写操作 未启用读写分离时不标记
+func (c *PrimaryPinCache) Pin(ctx context.Context, adminID int64) error {
+	conf := comm.BizConf.DBReplica
+	if !conf.Enable() || conf.PinWindow <= 0 {
+		return nil
+	}
+	return c.rdb.Set(ctx, c.getKey(adminID), 1, conf.PinWindow).Err()
+}
+
+func (c *PrimaryPinCache) Exists(ctx context.Context, adminID int64) (bool, error) {
+	n, err := c.rdb.Exists(ctx, c.getKey(adminID)).Result()
+	return n > 0, err
+}
+
+func (c *PrimaryPinCache) getKey(adminID int64) string {
+	return fmt.Sprintf("%s%d", PrimaryPinCachePrefix, adminID)
+}
@@ -0,0 +1,35 @@
+package cache
+
+import (
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"app/dao/model"
+	"app/schema"
+)
+
+const SchemaLocalCacheSize = 1024
+
+// compiledSchemaEntry 预编译问卷结构缓存项 version为问卷更新时间 问卷更新后自动重新编译
+type compiledSchemaEntry struct {
+	version  int64
+	compiled *schema.CompiledSchema
+}
+
+// compiledSchemaCache 进程内预编译问卷结构缓存 按问卷ID缓存
+var compiledSchemaCache, _ = lru.New[int64, compiledSchemaEntry](SchemaLocalCacheSize)
+
+// GetCompiledSchema 获取问卷的预编译结构 缓存版本与问卷更新时间不一致时重新编译
+// 返回的结构被多个请求共享 调用方不可修改
+func GetCompiledSchema(survey *model.Survey) (*schema.CompiledSchema, error) {
+	version := survey.UpdatedAt.UnixMilli()
+	if entry, ok := compiledSchemaCache.Get(survey.ID); ok && entry.version == version {
+		return entry.compiled, nil
+	}
+
+	compiled, err := schema.Compile(survey.Schema)
+	if err != nil {
+		return nil, err
+	}
+	compiledSchemaCache.Add(survey.ID, compiledSchemaEntry{version: version, compiled: compiled})
+	return compiled, nil
+}
@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+
+	"app/comm"
+	"app/dao/model"
+	"app/schema"
+)
+
+func testSurvey(t *testing.T, id int64, updatedAt time.Time, title string) *model.Survey {
+	t.Helper()
+	s := schema.SurveySchema{
+		Version: "1.0.0",
+		BaseConf: schema.BaseConf{
+			BeginTime: "2026-01-01 00:00:00",
+			EndTime:   "2026-12-31 23:59:59",
+		},
+		QuestionConf: schema.QuestionConf{Items: []schema.QuestionItem{
+			{ID: "q1", Type: comm.QuestionTypeText, Title: title, Valid: "*"},
+		}},
+	}
+	str, err := sonic.MarshalString(s)
+	if err != nil {
+		t.Fatalf("MarshalString() error = %v", err)
+	}
+	return &model.Survey{ID: id, Schema: str, UpdatedAt: updatedAt}
+}
+
+func TestGetCompiledSchemaRecompilesOnUpdate(t *testing.T) {
+	updatedAt := time.Now()
+	survey := testSurvey(t, -1, updatedAt, "旧标题")
+	t.Cleanup(func() {
+		compiledSchemaCache.Remove(survey.ID)
+	})
+
+	first, err := GetCompiledSchema(survey)
+	if err != nil {
+		t.Fatalf("GetCompiledSchema() error = %v", err)
+	}
+	cached, err := GetCompiledSchema(survey)
+	if err != nil {
+		t.Fatalf("GetCompiledSchema() error = %v", err)
+	}
+	if cached != first {
+		t.Error("GetCompiledSchema() recompiled an unchanged survey")
+	}
+
+	updated := testSurvey(t, survey.ID, updatedAt.Add(time.Second), "新标题")
+	recompiled, err := GetCompiledSchema(updated)
+	if err != nil {
+		t.Fatalf("GetCompiledSchema() error = %v", err)
+	}
+	if recompiled == first {
+		t.Fatal("GetCompiledSchema() returned stale schema after UpdatedAt changed")
+	}
+	if got := recompiled.Schema.QuestionConf.Items[0].Title; got != "新标题" {
+		t.Errorf("recompiled title = %q, want %q", got, "新标题")
+	}
+}
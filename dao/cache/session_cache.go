@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/redis/go-redis/v9"
+	"github.com/zjutjh/mygo/config"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/nedis"
+
+	"app/comm"
+)
+
+const (
+	SessionCachePrefix = "session:"
+
+	SessionScopeAdmin = "admin"
+	SessionScopeUser  = "user"
+)
+
+// RefreshSession 刷新Token对应的会话记录
+type RefreshSession struct {
+	Subject  string `json:"subject"`   // 会话主体
+	Identity string `json:"identity"`  // 登录身份JSON 不含会话信息
+	IssuedAt int64  `json:"issued_at"` // 签发时间 毫秒时间戳
+}
+
+// SessionCache 登录会话缓存 维护刷新Token、访问Token吊销名单及主体全部会话吊销时间
+type SessionCache struct {
+	rdb        redis.UniversalClient
+	scope      string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func NewSessionCache(scope string) *SessionCache {
+	accessTTL := config.Pick().GetDuration("jwt_" + scope + ".expiration")
+	if accessTTL <= 0 {
+		accessTTL = jwt.DefaultConfig.Expiration
+	}
+	refreshTTL := comm.BizConf.Session.UserRefreshExpiration
+	if scope == SessionScopeAdmin {
+		refreshTTL = comm.BizConf.Session.AdminRefreshExpiration
+	}
+	return &SessionCache{
+		rdb:        nedis.Pick(),
+		scope:      scope,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+	}
+}
+
+// SetRefresh 保存刷新Token会话记录
+func (c *SessionCache) SetRefresh(ctx context.Context, token string, session RefreshSession) error {
+	val, err := sonic.MarshalString(session)
+	if err != nil {
+		return err
+	}
+	return c.rdb.Set(ctx, c.getKey("refresh", token), val, c.refreshTTL).Err()
+}
+
+// TakeRefresh 取出并删除刷新Token会话记录 保证每个刷新Token仅可使用一次 不存在时返回nil
+func (c *SessionCache) TakeRefresh(ctx context.Context, token string) (*RefreshSession, error) {
+	val, err := c.rdb.GetDel(ctx, c.getKey("refresh", token)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var session RefreshSession
+	if err := sonic.UnmarshalString(val, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// DelRefresh 删除刷新Token会话记录
+func (c *SessionCache) DelRefresh(ctx context.Context, token string) error {
+	return c.rdb.Del(ctx, c.getKey("refresh", token)).Err()
+}
+
+// Deny 吊销访问Token 记录保留至Token自然过期
+func (c *SessionCache) Deny(ctx context.Context, session comm.TokenSession) error {
+	ttl := time.Until(time.UnixMilli(session.IssuedAt).Add(c.accessTTL))
+	if ttl <= 0 {
+		return nil
+	}
+	return c.rdb.Set(ctx, c.getKey("deny", session.TokenID), 1, ttl).Err()
+}
+
+// RevokeAll 吊销主体当前已签发的全部访问Token及刷新Token
+func (c *SessionCache) RevokeAll(ctx context.Context, subject string) error {
+	return c.rdb.Set(ctx, c.getKey("revoked", subject), time.Now().UnixMilli(), max(c.accessTTL, c.refreshTTL)).Err()
+}
+
+// Active 判断会话是否有效 tokenID为空时仅校验主体全部会话吊销时间
+func (c *SessionCache) Active(ctx context.Context, subject, tokenID string, issuedAt int64) (bool, error) {
+	pipe := c.rdb.Pipeline()
+	revoked := pipe.Get(ctx, c.getKey("revoked", subject))
+	var denied *redis.IntCmd
+	if tokenID != "" {
+		denied = pipe.Exists(ctx, c.getKey("deny", tokenID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return false, err
+	}
+
+	if denied != nil && denied.Val() > 0 {
+		return false, nil
+	}
+	if val := revoked.Val(); val != "" {
+		revokedAt, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return false, err
+		}
+		if issuedAt <= revokedAt {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (c *SessionCache) getKey(kind, value string) string {
+	return fmt.Sprintf("%s%s:%s:%s", SessionCachePrefix, c.scope, kind, value)
+}
@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zjutjh/mygo/nedis"
+
+	"app/comm"
+)
+
+const (
+	SettingCachePrefix = "setting:"
+	SettingCacheTTL    = 10 * time.Minute
+)
+
+// SettingCache 系统运行时配置缓存 配置项不存在时缓存空字符串
+type SettingCache struct {
+	rdb redis.UniversalClient
+}
+
+func NewSettingCache() *SettingCache {
+	return &SettingCache{
+		rdb: nedis.Pick(),
+	}
+}
+
+func (c *SettingCache) Set(ctx context.Context, name comm.SettingName, value string) error {
+	return c.rdb.Set(ctx, c.getKey(name), value, SettingCacheTTL).Err()
+}
+
+// Get 获取配置项值 缓存未命中时返回nil
+func (c *SettingCache) Get(ctx context.Context, name comm.SettingName) (*string, error) {
+	val, err := c.rdb.Get(ctx, c.getKey(name)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+func (c *SettingCache) Del(ctx context.Context, name comm.SettingName) error {
+	return c.rdb.Del(ctx, c.getKey(name)).Err()
+}
+
+func (c *SettingCache) getKey(name comm.SettingName) string {
+	return SettingCachePrefix + string(name)
+}
@@ -0,0 +1,273 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/redis/go-redis/v9"
+	"github.com/zjutjh/mygo/nedis"
+
+	"app/comm"
+	"app/schema"
+)
+
+const (
+	SubmitStreamKey      = "submit:stream" // 异步提交队列
+	SubmitStreamGroup    = "submit:worker" // 入库消费组
+	SubmitTicketPrefix   = "submit:ticket:"
+	SubmitTicketTTL      = 24 * time.Hour
+	SubmitReservedPrefix = "submit:reserved:"
+	SubmitReservedTTL    = 7 * 24 * time.Hour // 预占提交次数有效期 入库后即扣减 仅兜底异常情况
+	SubmitCodePrefix     = "submit:code:"     // 排队中答卷占用的一次性访问码 有效期同SubmitReservedTTL
+)
+
+// SubmitMessage 异步提交队列中的答卷 已完成校验及敏感答案加密
+type SubmitMessage struct {
+	Ticket      string            `json:"ticket"`                // 提交凭证
+	SurveyID    int64             `json:"survey_id"`             // 问卷ID
+	Username    string            `json:"username"`              // 用户名 未登录时为空
+	Data        string            `json:"data"`                  // 答卷内容
+	Score       int32             `json:"score"`                 // 得分 (测验)
+	AccessCode  string            `json:"access_code,omitempty"` // 一次性访问码 入库时使用
+	Stats       []schema.StatsRef `json:"stats,omitempty"`       // 答案计入的统计项
+	Reserved    bool              `json:"reserved,omitempty"`    // 是否预占了提交次数 入库后需扣减
+	SubmittedAt int64             `json:"submitted_at"`          // 提交时间 毫秒时间戳
+}
+
+// SubmitEntry 从队列读取的答卷 Message为nil表示消息格式错误
+type SubmitEntry struct {
+	ID      string
+	Message *SubmitMessage
+}
+
+// SubmitTicket 提交凭证状态
+type SubmitTicket struct {
+	Status comm.SubmitStatus `json:"status"`
+	Reason string            `json:"reason,omitempty"` // 失败原因
+}
+
+// SubmitQueueCache 异步提交队列 基于Redis Stream 答卷入库前维护提交凭证状态及已预占的提交次数
+type SubmitQueueCache struct {
+	rdb redis.UniversalClient
+}
+
+func NewSubmitQueueCache() *SubmitQueueCache {
+	return &SubmitQueueCache{
+		rdb: nedis.Pick(),
+	}
+}
+
+// Reserve 预占用户在问卷下的提交次数 返回预占后排队中的总提交次数及当日提交次数
+func (c *SubmitQueueCache) Reserve(ctx context.Context, surveyID int64, username string, submittedAt time.Time) (int64, int64, error) {
+	totalKey, dailyKey := c.reservedKeys(surveyID, username, submittedAt)
+	pipe := c.rdb.TxPipeline()
+	total := pipe.Incr(ctx, totalKey)
+	daily := pipe.Incr(ctx, dailyKey)
+	pipe.Expire(ctx, totalKey, SubmitReservedTTL)
+	pipe.Expire(ctx, dailyKey, SubmitReservedTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, 0, err
+	}
+	return total.Val(), daily.Val(), nil
+}
+
+// GetReserved 查询排队中答卷预占的总提交次数及当日提交次数 同步提交时计入提交限制
+func (c *SubmitQueueCache) GetReserved(ctx context.Context, surveyID int64, username string, now time.Time) (int64, int64, error) {
+	totalKey, dailyKey := c.reservedKeys(surveyID, username, now)
+	vals, err := c.rdb.MGet(ctx, totalKey, dailyKey).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+	counts := make([]int64, len(vals))
+	for i, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			continue
+		}
+		counts[i], err = strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	// 入库与扣减之间异常退出时计数可能小于0 按0处理
+	return max(counts[0], 0), max(counts[1], 0), nil
+}
+
+// Release 扣减预占的提交次数 答卷入库或提交失败后调用
+func (c *SubmitQueueCache) Release(ctx context.Context, surveyID int64, username string, submittedAt time.Time) error {
+	totalKey, dailyKey := c.reservedKeys(surveyID, username, submittedAt)
+	pipe := c.rdb.TxPipeline()
+	pipe.Decr(ctx, totalKey)
+	pipe.Decr(ctx, dailyKey)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ClaimCode 占用一次性访问码 并发提交同一访问码时仅一个请求可写入队列 返回是否占用成功
+// 入库后访问码由数据库标记为已使用 占用记录自然过期即可
+func (c *SubmitQueueCache) ClaimCode(ctx context.Context, surveyID int64, code string) (bool, error) {
+	return c.rdb.SetNX(ctx, c.codeKey(surveyID, code), 1, SubmitReservedTTL).Result()
+}
+
+// ReleaseCode 释放占用的一次性访问码 答卷未能入库时调用 访问码可再次使用
+func (c *SubmitQueueCache) ReleaseCode(ctx context.Context, surveyID int64, code string) error {
+	return c.rdb.Del(ctx, c.codeKey(surveyID, code)).Err()
+}
+
+// Enqueue 写入提交队列 同时记录提交凭证为排队中
+func (c *SubmitQueueCache) Enqueue(ctx context.Context, msg SubmitMessage) error {
+	val, err := sonic.MarshalString(msg)
+	if err != nil {
+		return err
+	}
+	ticket, err := sonic.MarshalString(SubmitTicket{Status: comm.SubmitStatusPending})
+	if err != nil {
+		return err
+	}
+	pipe := c.rdb.TxPipeline()
+	pipe.Set(ctx, SubmitTicketPrefix+msg.Ticket, ticket, SubmitTicketTTL)
+	pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: SubmitStreamKey,
+		Values: map[string]any{"msg": val},
+	})
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetTicket 查询提交凭证状态 不存在或已过期时返回nil
+func (c *SubmitQueueCache) GetTicket(ctx context.Context, ticket string) (*SubmitTicket, error) {
+	val, err := c.rdb.Get(ctx, SubmitTicketPrefix+ticket).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var res SubmitTicket
+	if err := sonic.UnmarshalString(val, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// SetTicket 更新提交凭证状态
+func (c *SubmitQueueCache) SetTicket(ctx context.Context, ticket string, status SubmitTicket) error {
+	val, err := sonic.MarshalString(status)
+	if err != nil {
+		return err
+	}
+	return c.rdb.Set(ctx, SubmitTicketPrefix+ticket, val, SubmitTicketTTL).Err()
+}
+
+// CreateGroup 创建入库消费组 已存在时忽略
+func (c *SubmitQueueCache) CreateGroup(ctx context.Context) error {
+	err := c.rdb.XGroupCreateMkStream(ctx, SubmitStreamKey, SubmitStreamGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+// Read 以消费者身份读取新答卷 队列为空时最多阻塞block
+func (c *SubmitQueueCache) Read(ctx context.Context, consumer string, count int64, block time.Duration) ([]SubmitEntry, error) {
+	streams, err := c.rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    SubmitStreamGroup,
+		Consumer: consumer,
+		Streams:  []string{SubmitStreamKey, ">"},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []SubmitEntry
+	for _, stream := range streams {
+		entries = append(entries, decodeSubmitEntries(stream.Messages)...)
+	}
+	return entries, nil
+}
+
+// Claim 认领其他消费者读取后超过minIdle仍未确认的答卷 用于消费者异常退出后继续入库
+func (c *SubmitQueueCache) Claim(ctx context.Context, consumer string, count int64, minIdle time.Duration) ([]SubmitEntry, error) {
+	messages, _, err := c.rdb.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   SubmitStreamKey,
+		Group:    SubmitStreamGroup,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Start:    "0-0",
+		Count:    count,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	return decodeSubmitEntries(messages), nil
+}
+
+// Deliveries 查询未确认答卷的投递次数 包括首次读取及每次认领 已确认的答卷不在结果中
+func (c *SubmitQueueCache) Deliveries(ctx context.Context, ids ...string) (map[string]int64, error) {
+	pipe := c.rdb.Pipeline()
+	cmds := make([]*redis.XPendingExtCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: SubmitStreamKey,
+			Group:  SubmitStreamGroup,
+			Start:  id,
+			End:    id,
+			Count:  1,
+		})
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	res := make(map[string]int64, len(ids))
+	for _, cmd := range cmds {
+		for _, p := range cmd.Val() {
+			res[p.ID] = p.RetryCount
+		}
+	}
+	return res, nil
+}
+
+// Ack 确认答卷已处理 并从队列中删除
+func (c *SubmitQueueCache) Ack(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	pipe := c.rdb.TxPipeline()
+	pipe.XAck(ctx, SubmitStreamKey, SubmitStreamGroup, ids...)
+	pipe.XDel(ctx, SubmitStreamKey, ids...)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *SubmitQueueCache) codeKey(surveyID int64, code string) string {
+	return fmt.Sprintf("%s%d:%s", SubmitCodePrefix, surveyID, code)
+}
+
+func (c *SubmitQueueCache) reservedKeys(surveyID int64, username string, submittedAt time.Time) (string, string) {
+	totalKey := fmt.Sprintf("%s%d:%s", SubmitReservedPrefix, surveyID, username)
+	return totalKey, totalKey + ":" + submittedAt.Format(time.DateOnly)
+}
+
+func decodeSubmitEntries(messages []redis.XMessage) []SubmitEntry {
+	entries := make([]SubmitEntry, 0, len(messages))
+	for _, m := range messages {
+		entry := SubmitEntry{ID: m.ID}
+		if val, ok := m.Values["msg"].(string); ok {
+			var msg SubmitMessage
+			if err := sonic.UnmarshalString(val, &msg); err == nil {
+				entry.Message = &msg
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -4,20 +4,46 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/bytedance/sonic"
+	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/redis/go-redis/v9"
+	"github.com/zjutjh/mygo/kit"
 	"github.com/zjutjh/mygo/nedis"
+	"github.com/zjutjh/mygo/nlog"
 
+	"app/comm"
 	"app/dao/model"
 )
 
 const (
 	SurveyCachePrefix = "survey:"
 	SurveyCacheTTL    = 5 * time.Minute
+
+	SurveyLocalCacheSize = 1024
+	SurveyLocalCacheTTL  = 10 * time.Second // 本地缓存有效期 兜底未收到失效通知的情况
+
+	SurveyInvalidateChannel = "survey:invalidate"
+	SurveyPathAddChannel    = "survey:path_add"
+
+	SurveyPathFilterMinSize = 10000 // 访问路径过滤器最小容量 为重建后新建的问卷预留空间
+	SurveyPathFilterFPRate  = 0.01  // 访问路径过滤器误判率
+
+	surveyMissingValue = "-" // 不存在的访问路径在Redis中的占位值
 )
 
+var (
+	// surveyLocalCache 进程内问卷缓存 多实例间通过Redis发布订阅同步失效
+	surveyLocalCache = expirable.NewLRU[string, *model.Survey](SurveyLocalCacheSize, nil, SurveyLocalCacheTTL)
+
+	// surveyPathFilter 进程内问卷访问路径过滤器 未构建完成时为nil 此时不拦截任何路径
+	surveyPathFilter atomic.Pointer[comm.BloomFilter]
+)
+
+// SurveyCache 问卷缓存 进程内LRU缓存 -> Redis缓存 两级
+// Get返回的问卷可能被多个请求共享 调用方不可修改
 type SurveyCache struct {
 	rdb redis.UniversalClient
 }
@@ -33,28 +59,138 @@ func (c *SurveyCache) Set(ctx context.Context, path string, survey *model.Survey
 	if err != nil {
 		return err
 	}
-	return c.rdb.Set(ctx, c.getKey(path), val, SurveyCacheTTL).Err()
+	if err := c.rdb.Set(ctx, c.getKey(path), val, SurveyCacheTTL).Err(); err != nil {
+		return err
+	}
+	surveyLocalCache.Add(path, survey)
+	return nil
 }
 
+// SetMissing 缓存不存在的访问路径 有效期内Get返回kit.ErrNotFound
+// 仅缓存于Redis 避免大量随机路径挤占进程内缓存
+func (c *SurveyCache) SetMissing(ctx context.Context, path string) error {
+	ttl := comm.BizConf.SurveyCache.NegativeTTL
+	if ttl <= 0 {
+		return nil
+	}
+	return c.rdb.Set(ctx, c.getKey(path), surveyMissingValue, ttl).Err()
+}
+
+// Get 查询问卷缓存 未缓存时返回nil 访问路径已缓存为不存在时返回kit.ErrNotFound
 func (c *SurveyCache) Get(ctx context.Context, path string) (*model.Survey, error) {
+	if survey, ok := surveyLocalCache.Get(path); ok {
+		comm.MetricSurveyCacheTotal.WithLabelValues("local_hit").Inc()
+		return survey, nil
+	}
+
 	val, err := c.rdb.Get(ctx, c.getKey(path)).Result()
 	if errors.Is(err, redis.Nil) {
+		comm.MetricSurveyCacheTotal.WithLabelValues("miss").Inc()
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
+	if val == surveyMissingValue {
+		comm.MetricSurveyCacheTotal.WithLabelValues("negative_hit").Inc()
+		return nil, kit.ErrNotFound
+	}
 	var survey model.Survey
 	if err := sonic.UnmarshalString(val, &survey); err != nil {
 		return nil, err
 	}
+	comm.MetricSurveyCacheTotal.WithLabelValues("redis_hit").Inc()
+	surveyLocalCache.Add(path, &survey)
 	return &survey, nil
 }
 
+// Del 删除问卷缓存 并通知所有实例删除进程内缓存
 func (c *SurveyCache) Del(ctx context.Context, path string) error {
-	return c.rdb.Del(ctx, c.getKey(path)).Err()
+	surveyLocalCache.Remove(path)
+	if err := c.rdb.Del(ctx, c.getKey(path)).Err(); err != nil {
+		return err
+	}
+	return c.rdb.Publish(ctx, SurveyInvalidateChannel, path).Err()
+}
+
+// AddPath 将新建问卷的访问路径加入过滤器 并通知所有实例
+func (c *SurveyCache) AddPath(ctx context.Context, path string) error {
+	if filter := surveyPathFilter.Load(); filter != nil {
+		filter.Add(path)
+	}
+	return c.rdb.Publish(ctx, SurveyPathAddChannel, path).Err()
 }
 
 func (c *SurveyCache) getKey(path string) string {
 	return fmt.Sprintf("%s%s", SurveyCachePrefix, path)
 }
+
+// SurveyPathMayExist 判断访问路径是否可能存在 返回false时问卷一定不存在
+// 过滤器未启用或未构建完成时始终返回true
+func SurveyPathMayExist(path string) bool {
+	filter := surveyPathFilter.Load()
+	if filter == nil || filter.Test(path) {
+		return true
+	}
+	comm.MetricSurveyCacheTotal.WithLabelValues("filtered").Inc()
+	return false
+}
+
+// rebuildSurveyPathFilter 按全部问卷访问路径重建过滤器 失败时停用过滤器
+func rebuildSurveyPathFilter(ctx context.Context, loadPaths func(context.Context) ([]string, error)) {
+	surveyPathFilter.Store(nil)
+	if !comm.BizConf.SurveyCache.PathFilter {
+		return
+	}
+	paths, err := loadPaths(ctx)
+	if err != nil {
+		nlog.Pick().WithError(err).Error("重建问卷访问路径过滤器失败")
+		return
+	}
+	filter := comm.NewBloomFilter(max(len(paths)*2, SurveyPathFilterMinSize), SurveyPathFilterFPRate)
+	for _, path := range paths {
+		filter.Add(path)
+	}
+	surveyPathFilter.Store(filter)
+}
+
+// ListenSurveyInvalidate 订阅问卷缓存失效及新建问卷通知 维护进程内缓存及访问路径过滤器 阻塞直至ctx结束
+// 订阅断开重连期间可能丢失通知 此时清空进程内缓存并停用过滤器 重新订阅成功后按loadPaths重建过滤器
+// 先订阅再加载访问路径 加载期间新建问卷的通知在重建完成后处理 不会遗漏
+func ListenSurveyInvalidate(ctx context.Context, loadPaths func(context.Context) ([]string, error)) {
+	pubsub := nedis.Pick().Subscribe(ctx, SurveyInvalidateChannel, SurveyPathAddChannel)
+	defer func() {
+		_ = pubsub.Close()
+	}()
+
+	for {
+		msg, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			nlog.Pick().WithError(err).Warn("接收问卷缓存失效通知失败")
+			surveyLocalCache.Purge()
+			surveyPathFilter.Store(nil)
+			time.Sleep(time.Second)
+			continue
+		}
+		switch m := msg.(type) {
+		case *redis.Subscription:
+			// 订阅 (含重连后重新订阅) 成功时清空 避免使用断开期间已失效的缓存
+			surveyLocalCache.Purge()
+			if m.Kind == "subscribe" && m.Channel == SurveyPathAddChannel {
+				rebuildSurveyPathFilter(ctx, loadPaths)
+			}
+		case *redis.Message:
+			switch m.Channel {
+			case SurveyInvalidateChannel:
+				surveyLocalCache.Remove(m.Payload)
+			case SurveyPathAddChannel:
+				if filter := surveyPathFilter.Load(); filter != nil {
+					filter.Add(m.Payload)
+				}
+			}
+		}
+	}
+}
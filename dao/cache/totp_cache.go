@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zjutjh/mygo/nedis"
+
+	"app/comm"
+)
+
+const (
+	TotpPendingCachePrefix = "admin:totp:pending:"
+	TotpPendingCacheTTL    = 10 * time.Minute
+
+	TotpUsedCachePrefix = "admin:totp:used:"
+	TotpUsedCacheTTL    = (2*comm.TotpSkew + 1) * comm.TotpPeriod * time.Second
+)
+
+// TotpCache 管理员二次验证缓存 维护待确认的TOTP密钥及已使用的验证码时间步长
+type TotpCache struct {
+	rdb redis.UniversalClient
+}
+
+func NewTotpCache() *TotpCache {
+	return &TotpCache{
+		rdb: nedis.Pick(),
+	}
+}
+
+// SetPending 保存待确认的TOTP密钥
+func (c *TotpCache) SetPending(ctx context.Context, adminID int64, secret string) error {
+	return c.rdb.Set(ctx, fmt.Sprintf("%s%d", TotpPendingCachePrefix, adminID), secret, TotpPendingCacheTTL).Err()
+}
+
+// GetPending 获取待确认的TOTP密钥 不存在或已过期时返回空字符串
+func (c *TotpCache) GetPending(ctx context.Context, adminID int64) (string, error) {
+	secret, err := c.rdb.Get(ctx, fmt.Sprintf("%s%d", TotpPendingCachePrefix, adminID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return secret, err
+}
+
+func (c *TotpCache) DelPending(ctx context.Context, adminID int64) error {
+	return c.rdb.Del(ctx, fmt.Sprintf("%s%d", TotpPendingCachePrefix, adminID)).Err()
+}
+
+// Use 标记验证码时间步长已使用 返回是否为首次使用
+func (c *TotpCache) Use(ctx context.Context, adminID, step int64) (bool, error) {
+	return c.rdb.SetNX(ctx, fmt.Sprintf("%s%d:%d", TotpUsedCachePrefix, adminID, step), 1, TotpUsedCacheTTL).Result()
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"app/comm"
+)
+
+// TracingHook Redis链路追踪 为每条命令及每个Pipeline创建Span 仅记录命令名 不记录键值
+type TracingHook struct{}
+
+func (TracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (TracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		span := startRedisSpan(ctx, "redis."+cmd.Name())
+		defer span.End()
+
+		err := next(ctx, cmd)
+		endRedisSpan(span, err)
+		return err
+	}
+}
+
+func (TracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		span := startRedisSpan(ctx, "redis.pipeline")
+		defer span.End()
+
+		names := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			names[i] = cmd.Name()
+		}
+		span.SetAttributes(attribute.StringSlice("db.redis.commands", names))
+
+		err := next(ctx, cmds)
+		endRedisSpan(span, err)
+		return err
+	}
+}
+
+func startRedisSpan(ctx context.Context, name string) trace.Span {
+	parent, requestID := comm.SpanParent(ctx)
+	_, span := comm.Tracer().Start(parent, name, trace.WithSpanKind(trace.SpanKindClient))
+	span.SetAttributes(attribute.String("db.system", "redis"))
+	if requestID != "" {
+		span.SetAttributes(attribute.String("request_id", requestID))
+	}
+	return span
+}
+
+func endRedisSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, redis.Nil) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -12,12 +12,15 @@ const TableNameResult = "result"
 
 // Result 答卷表
 type Result struct {
-	ID        int64     `gorm:"column:id;primaryKey;autoIncrement:true;comment:自增ID" json:"id"`                         // 自增ID
-	SurveyID  int64     `gorm:"column:survey_id;not null;comment:问卷ID" json:"survey_id"`                                // 问卷ID
-	Username  string    `gorm:"column:username;not null;comment:用户名" json:"username"`                                   // 用户名
-	Data      string    `gorm:"column:data;not null;comment:答卷内容" json:"data"`                                          // 答卷内容
-	CreatedAt time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP(3);comment:创建时间" json:"created_at"` // 创建时间
-	UpdatedAt time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP(3);comment:更新时间" json:"updated_at"` // 更新时间
+	ID           int64     `gorm:"column:id;primaryKey;autoIncrement:true;comment:自增ID" json:"id"`                         // 自增ID
+	SurveyID     int64     `gorm:"column:survey_id;not null;comment:问卷ID" json:"survey_id"`                                // 问卷ID
+	Username     string    `gorm:"column:username;not null;comment:用户名" json:"username"`                                   // 用户名
+	Data         string    `gorm:"column:data;not null;comment:答卷内容" json:"data"`                                          // 答卷内容
+	Score        int32     `gorm:"column:score;not null;comment:得分 (测验)" json:"score"`                                     // 得分 (测验)
+	AnonymizedAt int64     `gorm:"column:anonymized_at;not null;comment:匿名化时间 0表示未匿名化" json:"anonymized_at"`               // 匿名化时间 0表示未匿名化
+	Ticket       *string   `gorm:"column:ticket;comment:异步提交凭证 同步提交时为空" json:"ticket"`                                     // 异步提交凭证 同步提交时为空
+	CreatedAt    time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP(3);comment:创建时间" json:"created_at"` // 创建时间
+	UpdatedAt    time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP(3);comment:更新时间" json:"updated_at"` // 更新时间
 }
 
 // TableName Result's table name
@@ -31,6 +31,8 @@ func newAdmin(db *gorm.DB, opts ...gen.DOOption) admin {
 	_admin.Username = field.NewString(tableName, "username")
 	_admin.Password = field.NewString(tableName, "password")
 	_admin.Type = field.NewInt8(tableName, "type")
+	_admin.Status = field.NewInt8(tableName, "status")
+	_admin.TotpSecret = field.NewString(tableName, "totp_secret")
 	_admin.CreatedAt = field.NewTime(tableName, "created_at")
 	_admin.UpdatedAt = field.NewTime(tableName, "updated_at")
 
@@ -43,13 +45,15 @@ func newAdmin(db *gorm.DB, opts ...gen.DOOption) admin {
 type admin struct {
 	adminDo adminDo
 
-	ALL       field.Asterisk
-	ID        field.Int64  // 自增ID
-	Username  field.String // 用户名
-	Password  field.String // 密码
-	Type      field.Int8   // 类型 1-普通管理员 2-超级管理员
-	CreatedAt field.Time   // 创建时间
-	UpdatedAt field.Time   // 更新时间
+	ALL        field.Asterisk
+	ID         field.Int64  // 自增ID
+	Username   field.String // 用户名
+	Password   field.String // 密码
+	Type       field.Int8   // 类型 1-普通管理员 2-超级管理员
+	Status     field.Int8   // 状态 1-正常 2-禁用
+	TotpSecret field.String // TOTP密钥 为空表示未启用二次验证
+	CreatedAt  field.Time   // 创建时间
+	UpdatedAt  field.Time   // 更新时间
 
 	fieldMap map[string]field.Expr
 }
@@ -70,6 +74,8 @@ func (a *admin) updateTableName(table string) *admin {
 	a.Username = field.NewString(table, "username")
 	a.Password = field.NewString(table, "password")
 	a.Type = field.NewInt8(table, "type")
+	a.Status = field.NewInt8(table, "status")
+	a.TotpSecret = field.NewString(table, "totp_secret")
 	a.CreatedAt = field.NewTime(table, "created_at")
 	a.UpdatedAt = field.NewTime(table, "updated_at")
 
@@ -96,11 +102,13 @@ func (a *admin) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (a *admin) fillFieldMap() {
-	a.fieldMap = make(map[string]field.Expr, 6)
+	a.fieldMap = make(map[string]field.Expr, 8)
 	a.fieldMap["id"] = a.ID
 	a.fieldMap["username"] = a.Username
 	a.fieldMap["password"] = a.Password
 	a.fieldMap["type"] = a.Type
+	a.fieldMap["status"] = a.Status
+	a.fieldMap["totp_secret"] = a.TotpSecret
 	a.fieldMap["created_at"] = a.CreatedAt
 	a.fieldMap["updated_at"] = a.UpdatedAt
 }
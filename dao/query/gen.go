@@ -16,49 +16,94 @@ import (
 )
 
 var (
-	Q      = new(Query)
-	Admin  *admin
-	Result *result
-	Stats  *stats
-	Survey *survey
+	Q                 = new(Query)
+	Admin             *admin
+	AdminInvite       *adminInvite
+	AdminInviteUse    *adminInviteUse
+	AdminRecoveryCode *adminRecoveryCode
+	AuditLog          *auditLog
+	CascadeDataset    *cascadeDataset
+	QuestionBank      *questionBank
+	Result            *result
+	Stats             *stats
+	Survey            *survey
+	SurveyAccessCode  *surveyAccessCode
+	SurveyAllowlist   *surveyAllowlist
+	SystemSetting     *systemSetting
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
 	Admin = &Q.Admin
+	AdminInvite = &Q.AdminInvite
+	AdminInviteUse = &Q.AdminInviteUse
+	AdminRecoveryCode = &Q.AdminRecoveryCode
+	AuditLog = &Q.AuditLog
+	CascadeDataset = &Q.CascadeDataset
+	QuestionBank = &Q.QuestionBank
 	Result = &Q.Result
 	Stats = &Q.Stats
 	Survey = &Q.Survey
+	SurveyAccessCode = &Q.SurveyAccessCode
+	SurveyAllowlist = &Q.SurveyAllowlist
+	SystemSetting = &Q.SystemSetting
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:     db,
-		Admin:  newAdmin(db, opts...),
-		Result: newResult(db, opts...),
-		Stats:  newStats(db, opts...),
-		Survey: newSurvey(db, opts...),
+		db:                db,
+		Admin:             newAdmin(db, opts...),
+		AdminInvite:       newAdminInvite(db, opts...),
+		AdminInviteUse:    newAdminInviteUse(db, opts...),
+		AdminRecoveryCode: newAdminRecoveryCode(db, opts...),
+		AuditLog:          newAuditLog(db, opts...),
+		CascadeDataset:    newCascadeDataset(db, opts...),
+		QuestionBank:      newQuestionBank(db, opts...),
+		Result:            newResult(db, opts...),
+		Stats:             newStats(db, opts...),
+		Survey:            newSurvey(db, opts...),
+		SurveyAccessCode:  newSurveyAccessCode(db, opts...),
+		SurveyAllowlist:   newSurveyAllowlist(db, opts...),
+		SystemSetting:     newSystemSetting(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	Admin  admin
-	Result result
-	Stats  stats
-	Survey survey
+	Admin             admin
+	AdminInvite       adminInvite
+	AdminInviteUse    adminInviteUse
+	AdminRecoveryCode adminRecoveryCode
+	AuditLog          auditLog
+	CascadeDataset    cascadeDataset
+	QuestionBank      questionBank
+	Result            result
+	Stats             stats
+	Survey            survey
+	SurveyAccessCode  surveyAccessCode
+	SurveyAllowlist   surveyAllowlist
+	SystemSetting     systemSetting
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:     db,
-		Admin:  q.Admin.clone(db),
-		Result: q.Result.clone(db),
-		Stats:  q.Stats.clone(db),
-		Survey: q.Survey.clone(db),
+		db:                db,
+		Admin:             q.Admin.clone(db),
+		AdminInvite:       q.AdminInvite.clone(db),
+		AdminInviteUse:    q.AdminInviteUse.clone(db),
+		AdminRecoveryCode: q.AdminRecoveryCode.clone(db),
+		AuditLog:          q.AuditLog.clone(db),
+		CascadeDataset:    q.CascadeDataset.clone(db),
+		QuestionBank:      q.QuestionBank.clone(db),
+		Result:            q.Result.clone(db),
+		Stats:             q.Stats.clone(db),
+		Survey:            q.Survey.clone(db),
+		SurveyAccessCode:  q.SurveyAccessCode.clone(db),
+		SurveyAllowlist:   q.SurveyAllowlist.clone(db),
+		SystemSetting:     q.SystemSetting.clone(db),
 	}
 }
 
@@ -72,27 +117,54 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:     db,
-		Admin:  q.Admin.replaceDB(db),
-		Result: q.Result.replaceDB(db),
-		Stats:  q.Stats.replaceDB(db),
-		Survey: q.Survey.replaceDB(db),
+		db:                db,
+		Admin:             q.Admin.replaceDB(db),
+		AdminInvite:       q.AdminInvite.replaceDB(db),
+		AdminInviteUse:    q.AdminInviteUse.replaceDB(db),
+		AdminRecoveryCode: q.AdminRecoveryCode.replaceDB(db),
+		AuditLog:          q.AuditLog.replaceDB(db),
+		CascadeDataset:    q.CascadeDataset.replaceDB(db),
+		QuestionBank:      q.QuestionBank.replaceDB(db),
+		Result:            q.Result.replaceDB(db),
+		Stats:             q.Stats.replaceDB(db),
+		Survey:            q.Survey.replaceDB(db),
+		SurveyAccessCode:  q.SurveyAccessCode.replaceDB(db),
+		SurveyAllowlist:   q.SurveyAllowlist.replaceDB(db),
+		SystemSetting:     q.SystemSetting.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	Admin  IAdminDo
-	Result IResultDo
-	Stats  IStatsDo
-	Survey ISurveyDo
+	Admin             IAdminDo
+	AdminInvite       IAdminInviteDo
+	AdminInviteUse    IAdminInviteUseDo
+	AdminRecoveryCode IAdminRecoveryCodeDo
+	AuditLog          IAuditLogDo
+	CascadeDataset    ICascadeDatasetDo
+	QuestionBank      IQuestionBankDo
+	Result            IResultDo
+	Stats             IStatsDo
+	Survey            ISurveyDo
+	SurveyAccessCode  ISurveyAccessCodeDo
+	SurveyAllowlist   ISurveyAllowlistDo
+	SystemSetting     ISystemSettingDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		Admin:  q.Admin.WithContext(ctx),
-		Result: q.Result.WithContext(ctx),
-		Stats:  q.Stats.WithContext(ctx),
-		Survey: q.Survey.WithContext(ctx),
+		Admin:             q.Admin.WithContext(ctx),
+		AdminInvite:       q.AdminInvite.WithContext(ctx),
+		AdminInviteUse:    q.AdminInviteUse.WithContext(ctx),
+		AdminRecoveryCode: q.AdminRecoveryCode.WithContext(ctx),
+		AuditLog:          q.AuditLog.WithContext(ctx),
+		CascadeDataset:    q.CascadeDataset.WithContext(ctx),
+		QuestionBank:      q.QuestionBank.WithContext(ctx),
+		Result:            q.Result.WithContext(ctx),
+		Stats:             q.Stats.WithContext(ctx),
+		Survey:            q.Survey.WithContext(ctx),
+		SurveyAccessCode:  q.SurveyAccessCode.WithContext(ctx),
+		SurveyAllowlist:   q.SurveyAllowlist.WithContext(ctx),
+		SystemSetting:     q.SystemSetting.WithContext(ctx),
 	}
 }
 
@@ -31,6 +31,9 @@ func newResult(db *gorm.DB, opts ...gen.DOOption) result {
 	_result.SurveyID = field.NewInt64(tableName, "survey_id")
 	_result.Username = field.NewString(tableName, "username")
 	_result.Data = field.NewString(tableName, "data")
+	_result.Score = field.NewInt32(tableName, "score")
+	_result.AnonymizedAt = field.NewInt64(tableName, "anonymized_at")
+	_result.Ticket = field.NewString(tableName, "ticket")
 	_result.CreatedAt = field.NewTime(tableName, "created_at")
 	_result.UpdatedAt = field.NewTime(tableName, "updated_at")
 
@@ -43,13 +46,16 @@ func newResult(db *gorm.DB, opts ...gen.DOOption) result {
 type result struct {
 	resultDo resultDo
 
-	ALL       field.Asterisk
-	ID        field.Int64  // 自增ID
-	SurveyID  field.Int64  // 问卷ID
-	Username  field.String // 用户名
-	Data      field.String // 答卷内容
-	CreatedAt field.Time   // 创建时间
-	UpdatedAt field.Time   // 更新时间
+	ALL          field.Asterisk
+	ID           field.Int64  // 自增ID
+	SurveyID     field.Int64  // 问卷ID
+	Username     field.String // 用户名
+	Data         field.String // 答卷内容
+	Score        field.Int32  // 得分 (测验)
+	AnonymizedAt field.Int64  // 匿名化时间 0表示未匿名化
+	Ticket       field.String // 异步提交凭证 同步提交时为空
+	CreatedAt    field.Time   // 创建时间
+	UpdatedAt    field.Time   // 更新时间
 
 	fieldMap map[string]field.Expr
 }
@@ -70,6 +76,9 @@ func (r *result) updateTableName(table string) *result {
 	r.SurveyID = field.NewInt64(table, "survey_id")
 	r.Username = field.NewString(table, "username")
 	r.Data = field.NewString(table, "data")
+	r.Score = field.NewInt32(table, "score")
+	r.AnonymizedAt = field.NewInt64(table, "anonymized_at")
+	r.Ticket = field.NewString(table, "ticket")
 	r.CreatedAt = field.NewTime(table, "created_at")
 	r.UpdatedAt = field.NewTime(table, "updated_at")
 
@@ -96,11 +105,14 @@ func (r *result) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (r *result) fillFieldMap() {
-	r.fieldMap = make(map[string]field.Expr, 6)
+	r.fieldMap = make(map[string]field.Expr, 9)
 	r.fieldMap["id"] = r.ID
 	r.fieldMap["survey_id"] = r.SurveyID
 	r.fieldMap["username"] = r.Username
 	r.fieldMap["data"] = r.Data
+	r.fieldMap["score"] = r.Score
+	r.fieldMap["anonymized_at"] = r.AnonymizedAt
+	r.fieldMap["ticket"] = r.Ticket
 	r.fieldMap["created_at"] = r.CreatedAt
 	r.fieldMap["updated_at"] = r.UpdatedAt
 }
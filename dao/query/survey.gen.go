@@ -34,9 +34,12 @@ func newSurvey(db *gorm.DB, opts ...gen.DOOption) survey {
 	_survey.Path = field.NewString(tableName, "path")
 	_survey.Schema = field.NewString(tableName, "schema")
 	_survey.Status = field.NewInt8(tableName, "status")
+	_survey.FrozenAt = field.NewInt64(tableName, "frozen_at")
+	_survey.ArchivedAt = field.NewInt64(tableName, "archived_at")
+	_survey.ArchivingAt = field.NewInt64(tableName, "archiving_at")
 	_survey.CreatedAt = field.NewTime(tableName, "created_at")
 	_survey.UpdatedAt = field.NewTime(tableName, "updated_at")
-	_survey.DeletedAt = field.NewField(tableName, "deleted_at")
+	_survey.DeletedAt = field.NewUint(tableName, "deleted_at")
 
 	_survey.fillFieldMap()
 
@@ -47,17 +50,20 @@ func newSurvey(db *gorm.DB, opts ...gen.DOOption) survey {
 type survey struct {
 	surveyDo surveyDo
 
-	ALL       field.Asterisk
-	ID        field.Int64  // 自增ID
-	AdminID   field.Int64  // 所属管理员ID
-	Title     field.String // 标题
-	Type      field.Int8   // 类型 1-问卷 2-投票
-	Path      field.String // 访问路径
-	Schema    field.String // 结构
-	Status    field.Int8   // 状态 1-未发布 2-已发布
-	CreatedAt field.Time   // 创建时间
-	UpdatedAt field.Time   // 更新时间
-	DeletedAt field.Field  // 删除时间 (软删除)
+	ALL         field.Asterisk
+	ID          field.Int64  // 自增ID
+	AdminID     field.Int64  // 所属管理员ID
+	Title       field.String // 标题
+	Type        field.Int8   // 类型 1-问卷 2-投票 3-测验
+	Path        field.String // 访问路径
+	Schema      field.String // 结构
+	Status      field.Int8   // 状态 1-未发布 2-已发布
+	FrozenAt    field.Int64  // 暂停提交时间 0表示未暂停
+	ArchivedAt  field.Int64  // 答卷归档时间 0表示未归档
+	ArchivingAt field.Int64  // 答卷归档或恢复开始时间 0表示未在迁移
+	CreatedAt   field.Time   // 创建时间
+	UpdatedAt   field.Time   // 更新时间
+	DeletedAt   field.Uint   // 删除时间 (软删除)
 
 	fieldMap map[string]field.Expr
 }
@@ -81,9 +87,12 @@ func (s *survey) updateTableName(table string) *survey {
 	s.Path = field.NewString(table, "path")
 	s.Schema = field.NewString(table, "schema")
 	s.Status = field.NewInt8(table, "status")
+	s.FrozenAt = field.NewInt64(table, "frozen_at")
+	s.ArchivedAt = field.NewInt64(table, "archived_at")
+	s.ArchivingAt = field.NewInt64(table, "archiving_at")
 	s.CreatedAt = field.NewTime(table, "created_at")
 	s.UpdatedAt = field.NewTime(table, "updated_at")
-	s.DeletedAt = field.NewField(table, "deleted_at")
+	s.DeletedAt = field.NewUint(table, "deleted_at")
 
 	s.fillFieldMap()
 
@@ -108,7 +117,7 @@ func (s *survey) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (s *survey) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 10)
+	s.fieldMap = make(map[string]field.Expr, 13)
 	s.fieldMap["id"] = s.ID
 	s.fieldMap["admin_id"] = s.AdminID
 	s.fieldMap["title"] = s.Title
@@ -116,6 +125,9 @@ func (s *survey) fillFieldMap() {
 	s.fieldMap["path"] = s.Path
 	s.fieldMap["schema"] = s.Schema
 	s.fieldMap["status"] = s.Status
+	s.fieldMap["frozen_at"] = s.FrozenAt
+	s.fieldMap["archived_at"] = s.ArchivedAt
+	s.fieldMap["archiving_at"] = s.ArchivingAt
 	s.fieldMap["created_at"] = s.CreatedAt
 	s.fieldMap["updated_at"] = s.UpdatedAt
 	s.fieldMap["deleted_at"] = s.DeletedAt
@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zjutjh/mygo/ndb"
+	"gorm.io/gorm"
+
+	"app/dao/model"
+	"app/dao/query"
+)
+
+type AdminInviteRepo struct {
+	query *query.Query
+}
+
+func NewAdminInviteRepo(tx ...*query.Query) *AdminInviteRepo {
+	var q *query.Query
+	if len(tx) > 0 {
+		q = tx[0]
+	} else {
+		q = query.Use(ndb.Pick())
+	}
+	return &AdminInviteRepo{
+		query: q,
+	}
+}
+
+func (r *AdminInviteRepo) FindByID(ctx context.Context, id int64) (*model.AdminInvite, error) {
+	i := r.query.AdminInvite
+	record, err := i.WithContext(ctx).Where(i.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return record, err
+}
+
+func (r *AdminInviteRepo) FindByCode(ctx context.Context, code string) (*model.AdminInvite, error) {
+	i := r.query.AdminInvite
+	record, err := i.WithContext(ctx).Where(i.Code.Eq(code)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return record, err
+}
+
+func (r *AdminInviteRepo) FindPage(ctx context.Context, page, pageSize int) ([]*model.AdminInvite, int64, error) {
+	i := r.query.AdminInvite
+	do := i.WithContext(ctx)
+
+	list, err := do.Order(i.ID.Desc()).Limit(pageSize).Offset((page - 1) * pageSize).Find()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := do.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+func (r *AdminInviteRepo) Create(ctx context.Context, record *model.AdminInvite) error {
+	i := r.query.AdminInvite
+	return i.WithContext(ctx).Create(record)
+}
+
+// Consume 使用一次邀请码 邀请码已过期或次数已用尽时影响行数为0
+func (r *AdminInviteRepo) Consume(ctx context.Context, id int64, now time.Time) (int64, error) {
+	i := r.query.AdminInvite
+	result, err := i.WithContext(ctx).
+		Where(i.ID.Eq(id), i.UsedCount.LtCol(i.MaxUses), i.ExpireAt.Gt(now)).
+		UpdateSimple(i.UsedCount.Add(1))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+// Expire 使邀请码立即过期
+func (r *AdminInviteRepo) Expire(ctx context.Context, id int64, now time.Time) (int64, error) {
+	i := r.query.AdminInvite
+	result, err := i.WithContext(ctx).Where(i.ID.Eq(id), i.ExpireAt.Gt(now)).UpdateSimple(i.ExpireAt.Value(now))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,36 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/zjutjh/mygo/ndb"
+
+	"app/dao/model"
+	"app/dao/query"
+)
+
+type AdminInviteUseRepo struct {
+	query *query.Query
+}
+
+func NewAdminInviteUseRepo(tx ...*query.Query) *AdminInviteUseRepo {
+	var q *query.Query
+	if len(tx) > 0 {
+		q = tx[0]
+	} else {
+		q = query.Use(ndb.Pick())
+	}
+	return &AdminInviteUseRepo{
+		query: q,
+	}
+}
+
+func (r *AdminInviteUseRepo) FindListByInviteIDs(ctx context.Context, inviteIDs []int64) ([]*model.AdminInviteUse, error) {
+	u := r.query.AdminInviteUse
+	return u.WithContext(ctx).Where(u.InviteID.In(inviteIDs...)).Order(u.ID).Find()
+}
+
+func (r *AdminInviteUseRepo) Create(ctx context.Context, record *model.AdminInviteUse) error {
+	u := r.query.AdminInviteUse
+	return u.WithContext(ctx).Create(record)
+}
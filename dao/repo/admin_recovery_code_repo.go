@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/ndb"
+
+	"app/dao/model"
+	"app/dao/query"
+)
+
+type AdminRecoveryCodeRepo struct {
+	query *query.Query
+}
+
+func NewAdminRecoveryCodeRepo(tx ...*query.Query) *AdminRecoveryCodeRepo {
+	var q *query.Query
+	if len(tx) > 0 {
+		q = tx[0]
+	} else {
+		q = query.Use(ndb.Pick())
+	}
+	return &AdminRecoveryCodeRepo{
+		query: q,
+	}
+}
+
+// BatchCreate 为管理员批量创建恢复码 codeHashes为恢复码哈希列表
+func (r *AdminRecoveryCodeRepo) BatchCreate(ctx context.Context, adminID int64, codeHashes []string) error {
+	c := r.query.AdminRecoveryCode
+	return c.WithContext(ctx).Create(lo.Map(codeHashes, func(hash string, _ int) *model.AdminRecoveryCode {
+		return &model.AdminRecoveryCode{
+			AdminID:  adminID,
+			CodeHash: hash,
+		}
+	})...)
+}
+
+// Consume 使用一次恢复码 恢复码不存在或已使用时影响行数为0
+func (r *AdminRecoveryCodeRepo) Consume(ctx context.Context, adminID int64, codeHash string, usedAt int64) (int64, error) {
+	c := r.query.AdminRecoveryCode
+	result, err := c.WithContext(ctx).
+		Where(c.AdminID.Eq(adminID), c.CodeHash.Eq(codeHash), c.UsedAt.Eq(0)).
+		UpdateSimple(c.UsedAt.Value(usedAt))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *AdminRecoveryCodeRepo) CountUnused(ctx context.Context, adminID int64) (int64, error) {
+	c := r.query.AdminRecoveryCode
+	return c.WithContext(ctx).Where(c.AdminID.Eq(adminID), c.UsedAt.Eq(0)).Count()
+}
+
+func (r *AdminRecoveryCodeRepo) DeleteByAdminID(ctx context.Context, adminID int64) (int64, error) {
+	c := r.query.AdminRecoveryCode
+	result, err := c.WithContext(ctx).Where(c.AdminID.Eq(adminID)).Delete()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/zjutjh/mygo/ndb"
 	"gorm.io/gorm"
 
+	"app/comm"
 	"app/dao/model"
 	"app/dao/query"
 )
@@ -15,9 +16,15 @@ type AdminRepo struct {
 	query *query.Query
 }
 
-func NewAdminRepo() *AdminRepo {
+func NewAdminRepo(tx ...*query.Query) *AdminRepo {
+	var q *query.Query
+	if len(tx) > 0 {
+		q = tx[0]
+	} else {
+		q = query.Use(ndb.Pick())
+	}
 	return &AdminRepo{
-		query: query.Use(ndb.Pick()),
+		query: q,
 	}
 }
 
@@ -30,6 +37,15 @@ func (r *AdminRepo) FindByUsername(ctx context.Context, username string) (*model
 	return record, err
 }
 
+func (r *AdminRepo) FindByID(ctx context.Context, id int64) (*model.Admin, error) {
+	a := r.query.Admin
+	record, err := a.WithContext(ctx).Where(a.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return record, err
+}
+
 func (r *AdminRepo) FindListByIDs(ctx context.Context, ids []int64) ([]*model.Admin, error) {
 	a := r.query.Admin
 	return a.WithContext(ctx).Where(a.ID.In(ids...)).Find()
@@ -39,3 +55,68 @@ func (r *AdminRepo) Create(ctx context.Context, record *model.Admin) error {
 	a := r.query.Admin
 	return a.WithContext(ctx).Create(record)
 }
+
+// FindTotpEnabled 查询已启用二次验证的管理员
+func (r *AdminRepo) FindTotpEnabled(ctx context.Context) ([]*model.Admin, error) {
+	a := r.query.Admin
+	return a.WithContext(ctx).Where(a.TotpSecret.Neq("")).Find()
+}
+
+func (r *AdminRepo) UpdateTotpSecret(ctx context.Context, id int64, secret string) (int64, error) {
+	a := r.query.Admin
+	result, err := a.WithContext(ctx).Where(a.ID.Eq(id)).UpdateSimple(a.TotpSecret.Value(secret))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *AdminRepo) FindPage(ctx context.Context, page, pageSize int, status comm.AdminStatus, keyword string) ([]*model.Admin, int64, error) {
+	a := r.query.Admin
+	do := a.WithContext(ctx)
+	if status > 0 {
+		do = do.Where(a.Status.Eq(int8(status)))
+	}
+	if keyword != "" {
+		do = do.Where(a.Username.Like("%" + keyword + "%"))
+	}
+
+	list, err := do.Omit(a.Password).Order(a.ID.Desc()).Limit(pageSize).Offset((page - 1) * pageSize).Find()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := do.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+func (r *AdminRepo) UpdateStatus(ctx context.Context, id int64, status comm.AdminStatus) (int64, error) {
+	a := r.query.Admin
+	result, err := a.WithContext(ctx).Where(a.ID.Eq(id)).UpdateSimple(a.Status.Value(int8(status)))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *AdminRepo) UpdatePassword(ctx context.Context, id int64, password string) (int64, error) {
+	a := r.query.Admin
+	result, err := a.WithContext(ctx).Where(a.ID.Eq(id)).UpdateSimple(a.Password.Value(password))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *AdminRepo) DeleteByID(ctx context.Context, id int64) (int64, error) {
+	a := r.query.Admin
+	result, err := a.WithContext(ctx).Where(a.ID.Eq(id)).Delete()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
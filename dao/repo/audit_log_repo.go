@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/zjutjh/mygo/ndb"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/query"
+)
+
+type AuditLogRepo struct {
+	query *query.Query
+}
+
+func NewAuditLogRepo(tx ...*query.Query) *AuditLogRepo {
+	var q *query.Query
+	if len(tx) > 0 {
+		q = tx[0]
+	} else {
+		q = query.Use(ndb.Pick())
+	}
+	return &AuditLogRepo{
+		query: q,
+	}
+}
+
+// AuditEntry 审计日志条目 Detail序列化为JSON存储
+type AuditEntry struct {
+	AdminID    int64
+	Action     comm.AuditAction
+	TargetType comm.AuditTarget
+	TargetID   int64
+	Detail     any
+	IP         string
+	RequestID  string
+}
+
+// AuditFilter 审计日志查询条件 零值表示不限
+type AuditFilter struct {
+	AdminID    int64
+	Action     comm.AuditAction
+	TargetType comm.AuditTarget
+	TargetID   int64
+	BeginTime  time.Time
+	EndTime    time.Time
+}
+
+func (r *AuditLogRepo) Record(ctx context.Context, entry AuditEntry) error {
+	detail, err := sonic.MarshalString(entry.Detail)
+	if err != nil {
+		return err
+	}
+	l := r.query.AuditLog
+	return l.WithContext(ctx).Create(&model.AuditLog{
+		AdminID:    entry.AdminID,
+		Action:     string(entry.Action),
+		TargetType: string(entry.TargetType),
+		TargetID:   entry.TargetID,
+		Detail:     detail,
+		IP:         entry.IP,
+		RequestID:  entry.RequestID,
+	})
+}
+
+func (r *AuditLogRepo) FindPage(ctx context.Context, page, pageSize int, filter AuditFilter) ([]*model.AuditLog, int64, error) {
+	l := r.query.AuditLog
+	do := l.WithContext(ctx)
+	if filter.AdminID != 0 {
+		do = do.Where(l.AdminID.Eq(filter.AdminID))
+	}
+	if filter.Action != "" {
+		do = do.Where(l.Action.Eq(string(filter.Action)))
+	}
+	if filter.TargetType != "" {
+		do = do.Where(l.TargetType.Eq(string(filter.TargetType)))
+	}
+	if filter.TargetID != 0 {
+		do = do.Where(l.TargetID.Eq(filter.TargetID))
+	}
+	if !filter.BeginTime.IsZero() {
+		do = do.Where(l.CreatedAt.Gte(filter.BeginTime))
+	}
+	if !filter.EndTime.IsZero() {
+		do = do.Where(l.CreatedAt.Lte(filter.EndTime))
+	}
+
+	list, err := do.Order(l.ID.Desc()).Limit(pageSize).Offset((page - 1) * pageSize).Find()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := do.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
@@ -0,0 +1,136 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bytedance/sonic"
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/ndb"
+	"gorm.io/gorm"
+
+	"app/dao/model"
+	"app/dao/query"
+	"app/schema"
+)
+
+type CascadeDatasetRepo struct {
+	query *query.Query
+}
+
+func NewCascadeDatasetRepo(tx ...*query.Query) *CascadeDatasetRepo {
+	var q *query.Query
+	if len(tx) > 0 {
+		q = tx[0]
+	} else {
+		q = query.Use(ndb.Pick())
+	}
+	return &CascadeDatasetRepo{
+		query: q,
+	}
+}
+
+func (r *CascadeDatasetRepo) FindByID(ctx context.Context, id int64) (*model.CascadeDataset, error) {
+	d := r.query.CascadeDataset
+	record, err := d.WithContext(ctx).Where(d.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return record, err
+}
+
+func (r *CascadeDatasetRepo) FindListByIDs(ctx context.Context, ids []int64) ([]*model.CascadeDataset, error) {
+	d := r.query.CascadeDataset
+	return d.WithContext(ctx).Where(d.ID.In(ids...)).Find()
+}
+
+func (r *CascadeDatasetRepo) FindPage(ctx context.Context, page, pageSize int, keyword string) ([]*model.CascadeDataset, int64, error) {
+	d := r.query.CascadeDataset
+	do := d.WithContext(ctx)
+	if keyword != "" {
+		do = do.Where(d.Name.Like("%" + keyword + "%"))
+	}
+
+	list, err := do.Omit(d.Data).Order(d.ID.Desc()).Limit(pageSize).Offset((page - 1) * pageSize).Find()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := do.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+func (r *CascadeDatasetRepo) Create(ctx context.Context, record *model.CascadeDataset) error {
+	d := r.query.CascadeDataset
+	return d.WithContext(ctx).Create(record)
+}
+
+func (r *CascadeDatasetRepo) Update(ctx context.Context, id int64, name, data string) (int64, error) {
+	d := r.query.CascadeDataset
+	result, err := d.WithContext(ctx).Where(d.ID.Eq(id)).UpdateSimple(d.Name.Value(name), d.Data.Value(data))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *CascadeDatasetRepo) DeleteByID(ctx context.Context, id int64) (int64, error) {
+	d := r.query.CascadeDataset
+	result, err := d.WithContext(ctx).Where(d.ID.Eq(id)).Delete()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *CascadeDatasetRepo) TransferAdmin(ctx context.Context, fromAdminID, toAdminID int64) (int64, error) {
+	d := r.query.CascadeDataset
+	result, err := d.WithContext(ctx).Where(d.AdminID.Eq(fromAdminID)).UpdateSimple(d.AdminID.Value(toAdminID))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+// FillSchema 以引用的级联数据集内容填充级联题选项树 数据集后续变更不影响已保存的问卷
+// 数据集已被删除时解除引用并保留题目中现有的选项树
+func (r *CascadeDatasetRepo) FillSchema(ctx context.Context, s *schema.SurveySchema) error {
+	ids := lo.Uniq(lo.FilterMap(s.QuestionConf.Items, func(item schema.QuestionItem, _ int) (int64, bool) {
+		return item.DatasetID, item.IsCascadeType() && item.DatasetID > 0
+	}))
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// 查询数据集列表
+	list, err := r.FindListByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	datasetMap := lo.KeyBy(list, func(item *model.CascadeDataset) int64 {
+		return item.ID
+	})
+
+	// 填充选项树
+	for i := range s.QuestionConf.Items {
+		item := &s.QuestionConf.Items[i]
+		if !item.IsCascadeType() || item.DatasetID == 0 {
+			continue
+		}
+		dataset, ok := datasetMap[item.DatasetID]
+		if !ok {
+			item.DatasetID = 0
+			continue
+		}
+		var nodes []schema.CascadeNode
+		if err := sonic.UnmarshalString(dataset.Data, &nodes); err != nil {
+			return err
+		}
+		item.CascadeOptions = nodes
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zjutjh/mygo/ndb"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/query"
+)
+
+type QuestionBankRepo struct {
+	query *query.Query
+}
+
+func NewQuestionBankRepo(tx ...*query.Query) *QuestionBankRepo {
+	var q *query.Query
+	if len(tx) > 0 {
+		q = tx[0]
+	} else {
+		q = query.Use(ndb.Pick())
+	}
+	return &QuestionBankRepo{
+		query: q,
+	}
+}
+
+func (r *QuestionBankRepo) FindByID(ctx context.Context, id int64) (*model.QuestionBank, error) {
+	b := r.query.QuestionBank
+	record, err := b.WithContext(ctx).Where(b.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return record, err
+}
+
+// FindVisibleListByIDs 查询管理员可见的题目列表 (共享题目及本人私有题目)
+func (r *QuestionBankRepo) FindVisibleListByIDs(ctx context.Context, adminID int64, ids []int64) ([]*model.QuestionBank, error) {
+	b := r.query.QuestionBank
+	return b.WithContext(ctx).Where(b.ID.In(ids...)).
+		Where(b.WithContext(ctx).Where(b.Scope.Eq(int8(comm.BankScopeShared))).Or(b.AdminID.Eq(adminID))).
+		Find()
+}
+
+// FindPage 分页查询管理员可见的题目 scope为0时查询全部可见范围
+func (r *QuestionBankRepo) FindPage(ctx context.Context, page, pageSize int, adminID int64, scope comm.BankScope, qType comm.QuestionType, tag, keyword string) ([]*model.QuestionBank, int64, error) {
+	b := r.query.QuestionBank
+	do := b.WithContext(ctx)
+	switch scope {
+	case comm.BankScopePrivate:
+		do = do.Where(b.Scope.Eq(int8(comm.BankScopePrivate)), b.AdminID.Eq(adminID))
+	case comm.BankScopeShared:
+		do = do.Where(b.Scope.Eq(int8(comm.BankScopeShared)))
+	default:
+		do = do.Where(b.WithContext(ctx).Where(b.Scope.Eq(int8(comm.BankScopeShared))).
+			Or(b.Scope.Eq(int8(comm.BankScopePrivate)), b.AdminID.Eq(adminID)))
+	}
+	if qType != "" {
+		do = do.Where(b.Type.Eq(string(qType)))
+	}
+	if tag != "" {
+		do = do.Where(gen.Cond(clause.Expr{SQL: "JSON_CONTAINS(`tags`, JSON_QUOTE(?))", Vars: []any{tag}})...)
+	}
+	if keyword != "" {
+		do = do.Where(b.Title.Like("%" + keyword + "%"))
+	}
+
+	list, err := do.Order(b.ID.Desc()).Limit(pageSize).Offset((page - 1) * pageSize).Find()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := do.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+func (r *QuestionBankRepo) Create(ctx context.Context, record *model.QuestionBank) error {
+	b := r.query.QuestionBank
+	return b.WithContext(ctx).Create(record)
+}
+
+func (r *QuestionBankRepo) UpdateTags(ctx context.Context, id int64, tags string) (int64, error) {
+	b := r.query.QuestionBank
+	result, err := b.WithContext(ctx).Where(b.ID.Eq(id)).UpdateSimple(b.Tags.Value(tags))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *QuestionBankRepo) DeleteByID(ctx context.Context, id int64) (int64, error) {
+	b := r.query.QuestionBank
+	result, err := b.WithContext(ctx).Where(b.ID.Eq(id)).Delete()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *QuestionBankRepo) TransferAdmin(ctx context.Context, fromAdminID, toAdminID int64) (int64, error) {
+	b := r.query.QuestionBank
+	result, err := b.WithContext(ctx).Where(b.AdminID.Eq(fromAdminID)).UpdateSimple(b.AdminID.Value(toAdminID))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
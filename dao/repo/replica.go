@@ -0,0 +1,40 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"app/comm"
+)
+
+// ReplicaResolver 从库解析器名称 仅显式指定的查询读从库
+const ReplicaResolver = "replica"
+
+const primaryPinnedKey = "app:db_primary_pinned"
+
+// PinPrimary 固定当前请求的查询使用主库 用于写后立即读等需要读己之写的场景
+func PinPrimary(ctx *gin.Context) {
+	ctx.Set(primaryPinnedKey, true)
+}
+
+// replicaDao 支持替换底层连接的查询对象 gen生成的XxxDo均满足
+type replicaDao interface {
+	UnderlyingDB() *gorm.DB
+	ReplaceDB(db *gorm.DB)
+}
+
+// readReplica 重查询读从库 未启用读写分离或当前请求已固定主库时使用主库 事务内的查询始终使用主库
+// gen的Clauses不接受解析器选项 需在底层连接上指定
+func readReplica[T replicaDao](ctx context.Context, do T) T {
+	if !comm.BizConf.DBReplica.Enable() {
+		return do
+	}
+	if pinned, _ := ctx.Value(primaryPinnedKey).(bool); pinned {
+		return do
+	}
+	do.ReplaceDB(do.UnderlyingDB().Clauses(dbresolver.Use(ReplicaResolver)))
+	return do
+}
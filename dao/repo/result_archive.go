@@ -0,0 +1,194 @@
+package repo
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm/schema"
+
+	"app/dao/model"
+	"app/dao/query"
+)
+
+// ResultArchiveTable 答卷归档表 结构与答卷表一致 已归档问卷的答卷整体移入此表
+const ResultArchiveTable = "result_archive"
+
+// resultAllTable 答卷表与答卷归档表的合并查询 按答卷模型列名显式合并 别名与答卷表一致
+var resultAllTable = sync.OnceValue(func() string {
+	s, err := schema.Parse(&model.Result{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		panic(err)
+	}
+	columns := strings.Join(lo.Map(s.DBNames, func(name string, _ int) string {
+		return "`" + name + "`"
+	}), ", ")
+	return fmt.Sprintf("(SELECT %[1]s FROM `%[2]s` UNION ALL SELECT %[1]s FROM `%[3]s`) AS %[2]s",
+		columns, model.TableNameResult, ResultArchiveTable)
+})
+
+const resultArchiveBatchSize = 500
+
+// Archive 返回操作答卷归档表的ResultRepo
+func (r *ResultRepo) Archive() *ResultRepo {
+	q := *r.query
+	q.Result = *q.Result.Table(ResultArchiveTable)
+	return &ResultRepo{
+		query: &q,
+	}
+}
+
+// WithArchive 返回同时查询答卷表及答卷归档表的ResultRepo 仅可用于查询
+func (r *ResultRepo) WithArchive() *ResultRepo {
+	db := r.query.Result.WithContext(context.Background()).UnderlyingDB()
+	q := *r.query
+	q.Result = query.Use(db.Table(resultAllTable())).Result
+	return &ResultRepo{
+		query: &q,
+	}
+}
+
+// ForSurvey 按问卷归档状态选择答卷表或答卷归档表 归档或恢复过程中答卷分布于两表 合并查询
+func (r *ResultRepo) ForSurvey(survey *model.Survey) *ResultRepo {
+	switch {
+	case survey.ArchivingAt > 0:
+		return r.WithArchive()
+	case survey.ArchivedAt > 0:
+		return r.Archive()
+	}
+	return r
+}
+
+// FindListBySurveyID 按ID顺序查询问卷下的答卷
+func (r *ResultRepo) FindListBySurveyID(ctx context.Context, surveyID int64, limit int) ([]*model.Result, error) {
+	q := r.query.Result
+	return q.WithContext(ctx).Where(q.SurveyID.Eq(surveyID)).Order(q.ID).Limit(limit).Find()
+}
+
+// FindListByUsernameWithArchive 查询用户在答卷表及归档表中的全部答卷 按ID排序
+func (r *ResultRepo) FindListByUsernameWithArchive(ctx context.Context, username string) ([]*model.Result, error) {
+	list, err := r.FindListByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	archived, err := r.Archive().FindListByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	list = append(list, archived...)
+	slices.SortFunc(list, func(a, b *model.Result) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return list, nil
+}
+
+// FindPageByUsernameWithArchive 分页查询用户在答卷表及归档表中的答卷 先答卷表后归档表 各自按ID倒序
+func (r *ResultRepo) FindPageByUsernameWithArchive(ctx context.Context, username string, page, pageSize int) ([]*model.Result, int64, error) {
+	q := r.query.Result
+	total, err := q.WithContext(ctx).Where(q.Username.Eq(username)).Count()
+	if err != nil {
+		return nil, 0, err
+	}
+	archive := r.Archive()
+	a := archive.query.Result
+	archivedTotal, err := a.WithContext(ctx).Where(a.Username.Eq(username)).Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	list := make([]*model.Result, 0, pageSize)
+	if int64(offset) < total {
+		list, err = q.WithContext(ctx).Where(q.Username.Eq(username)).Order(q.ID.Desc()).Limit(pageSize).Offset(offset).Find()
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	if remain := pageSize - len(list); remain > 0 && archivedTotal > 0 {
+		archived, err := a.WithContext(ctx).Where(a.Username.Eq(username)).Order(a.ID.Desc()).
+			Limit(remain).Offset(max(offset-int(total), 0)).Find()
+		if err != nil {
+			return nil, 0, err
+		}
+		list = append(list, archived...)
+	}
+
+	return list, total + archivedTotal, nil
+}
+
+// ArchiveSurveyResults 将问卷答卷分批移入归档表 返回移动的答卷数量
+// 调用前须标记问卷归档中 每批答卷在独立事务内移动 最后一批与标记问卷已归档在同一事务内完成
+// 归档过程中通过ForSurvey同时查询两表 中断后再次执行将继续移动剩余答卷
+func ArchiveSurveyResults(ctx context.Context, surveyID int64, now time.Time) (int, error) {
+	return moveResults(ctx, surveyID, false, now.UnixMilli())
+}
+
+// RestoreSurveyResults 将问卷答卷分批移回答卷表 返回移动的答卷数量
+// 调用前须标记问卷恢复中 全部移回后取消问卷归档标记
+func RestoreSurveyResults(ctx context.Context, surveyID int64) (int, error) {
+	return moveResults(ctx, surveyID, true, 0)
+}
+
+// moveResults 分批移动问卷答卷 每批一个事务 restore为true时由归档表移回答卷表
+// 批次移动完毕后在同一事务内移动此间新写入的答卷并更新归档时间 清除归档中标记
+func moveResults(ctx context.Context, surveyID int64, restore bool, archivedAt int64) (int, error) {
+	count := 0
+	for {
+		n := 0
+		err := Transaction(func(tx *query.Query) error {
+			var err error
+			n, err = moveResultBatch(ctx, NewResultRepo(tx), surveyID, restore)
+			return err
+		})
+		count += n
+		if err != nil {
+			return count, err
+		}
+		if n == 0 {
+			break
+		}
+	}
+
+	err := Transaction(func(tx *query.Query) error {
+		for {
+			n, err := moveResultBatch(ctx, NewResultRepo(tx), surveyID, restore)
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				break
+			}
+			count += n
+		}
+		_, err := NewSurveyRepo(tx).FinishArchiving(ctx, surveyID, archivedAt)
+		return err
+	})
+	return count, err
+}
+
+// moveResultBatch 将一批问卷答卷复制至目标表后删除 保留答卷ID 返回移动的答卷数量
+func moveResultBatch(ctx context.Context, resultRepo *ResultRepo, surveyID int64, restore bool) (int, error) {
+	from, to := resultRepo, resultRepo.Archive()
+	if restore {
+		from, to = to, from
+	}
+	list, err := from.FindListBySurveyID(ctx, surveyID, resultArchiveBatchSize)
+	if err != nil || len(list) == 0 {
+		return 0, err
+	}
+	if err := to.BatchCreate(ctx, list); err != nil {
+		return 0, err
+	}
+	ids := lo.Map(list, func(res *model.Result, _ int) int64 {
+		return res.ID
+	})
+	if _, err := from.DeleteByIDs(ctx, ids); err != nil {
+		return 0, err
+	}
+	return len(list), nil
+}
@@ -2,9 +2,12 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/zjutjh/mygo/ndb"
+	"gorm.io/gen"
+	"gorm.io/gorm"
 
 	"app/dao/model"
 	"app/dao/query"
@@ -26,9 +29,19 @@ func NewResultRepo(tx ...*query.Query) *ResultRepo {
 	}
 }
 
+func (r *ResultRepo) FindByID(ctx context.Context, id int64) (*model.Result, error) {
+	q := r.query.Result
+	record, err := q.WithContext(ctx).Where(q.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return record, err
+}
+
+// FindPage 分页查询问卷下的答卷 启用读写分离时读从库
 func (r *ResultRepo) FindPage(ctx context.Context, surveyID int64, page, pageSize int) ([]*model.Result, int64, error) {
 	q := r.query.Result
-	do := q.WithContext(ctx).Where(q.SurveyID.Eq(surveyID))
+	do := readReplica(ctx, q.WithContext(ctx)).Where(q.SurveyID.Eq(surveyID))
 
 	list, err := do.Order(q.ID.Desc()).Limit(pageSize).Offset((page - 1) * pageSize).Find()
 	if err != nil {
@@ -43,9 +56,33 @@ func (r *ResultRepo) FindPage(ctx context.Context, surveyID int64, page, pageSiz
 	return list, total, nil
 }
 
+// CountBySurveyID 统计问卷答卷数量 启用读写分离时读从库
 func (r *ResultRepo) CountBySurveyID(ctx context.Context, surveyID int64) (int64, error) {
 	q := r.query.Result
-	return q.WithContext(ctx).Where(q.SurveyID.Eq(surveyID)).Count()
+	return readReplica(ctx, q.WithContext(ctx)).Where(q.SurveyID.Eq(surveyID)).Count()
+}
+
+// FindInBatches 分批遍历问卷下的答卷 启用读写分离时读从库
+func (r *ResultRepo) FindInBatches(ctx context.Context, surveyID int64, batchSize int, fc func(list []*model.Result) error) error {
+	q := r.query.Result
+	var list []*model.Result
+	return readReplica(ctx, q.WithContext(ctx)).Where(q.SurveyID.Eq(surveyID)).FindInBatches(&list, batchSize, func(_ gen.Dao, _ int) error {
+		return fc(list)
+	})
+}
+
+type ScoreCount struct {
+	Score int32
+	Count int64
+}
+
+// CountGroupByScore 按分数统计问卷答卷数量 启用读写分离时读从库
+func (r *ResultRepo) CountGroupByScore(ctx context.Context, surveyID int64) ([]ScoreCount, error) {
+	q := r.query.Result
+	var list []ScoreCount
+	err := readReplica(ctx, q.WithContext(ctx)).Select(q.Score, q.ID.Count().As("count")).
+		Where(q.SurveyID.Eq(surveyID)).Group(q.Score).Order(q.Score).Scan(&list)
+	return list, err
 }
 
 type TimeRange struct {
@@ -53,6 +90,7 @@ type TimeRange struct {
 	End   time.Time
 }
 
+// CountByUser 统计用户提交次数 用于提交限制检查 始终读主库
 func (r *ResultRepo) CountByUser(ctx context.Context, sid int64, user string, tr *TimeRange) (int64, error) {
 	q := r.query.Result
 	db := q.WithContext(ctx).Where(q.SurveyID.Eq(sid), q.Username.Eq(user))
@@ -62,7 +100,90 @@ func (r *ResultRepo) CountByUser(ctx context.Context, sid int64, user string, tr
 	return db.Count()
 }
 
+// FindAllInBatches 分批遍历全部答卷
+func (r *ResultRepo) FindAllInBatches(ctx context.Context, batchSize int, fc func(list []*model.Result) error) error {
+	q := r.query.Result
+	var list []*model.Result
+	return q.WithContext(ctx).FindInBatches(&list, batchSize, func(_ gen.Dao, _ int) error {
+		return fc(list)
+	})
+}
+
 func (r *ResultRepo) Create(ctx context.Context, result *model.Result) error {
 	res := r.query.Result
 	return res.WithContext(ctx).Create(result)
 }
+
+// BatchCreate 批量创建答卷 创建后回填答卷ID
+func (r *ResultRepo) BatchCreate(ctx context.Context, results []*model.Result) error {
+	res := r.query.Result
+	return res.WithContext(ctx).CreateInBatches(results, 100)
+}
+
+// FindExistingTickets 查询已入库的异步提交凭证
+func (r *ResultRepo) FindExistingTickets(ctx context.Context, tickets []string) ([]string, error) {
+	q := r.query.Result
+	var list []string
+	err := q.WithContext(ctx).Where(q.Ticket.In(tickets...)).Pluck(q.Ticket, &list)
+	return list, err
+}
+
+type UserSubmitStats struct {
+	Username     string
+	Count        int64
+	LastSubmitAt time.Time
+}
+
+// StatsByUsernames 统计指定用户在问卷下的提交次数及最近提交时间 未提交的用户不返回
+func (r *ResultRepo) StatsByUsernames(ctx context.Context, surveyID int64, usernames []string) ([]UserSubmitStats, error) {
+	q := r.query.Result
+	var list []UserSubmitStats
+	err := q.WithContext(ctx).Select(q.Username, q.ID.Count().As("count"), q.CreatedAt.Max().As("last_submit_at")).
+		Where(q.SurveyID.Eq(surveyID), q.Username.In(usernames...)).Group(q.Username).Scan(&list)
+	return list, err
+}
+
+func (r *ResultRepo) UpdateData(ctx context.Context, id int64, data string) error {
+	q := r.query.Result
+	_, err := q.WithContext(ctx).Where(q.ID.Eq(id)).UpdateSimple(q.Data.Value(data))
+	return err
+}
+
+func (r *ResultRepo) FindListByUsername(ctx context.Context, username string) ([]*model.Result, error) {
+	q := r.query.Result
+	return q.WithContext(ctx).Where(q.Username.Eq(username)).Order(q.ID).Find()
+}
+
+// FindIDsBySurveyID 按ID顺序查询问卷下的答卷ID 用于分批删除
+func (r *ResultRepo) FindIDsBySurveyID(ctx context.Context, surveyID int64, limit int) ([]int64, error) {
+	q := r.query.Result
+	var ids []int64
+	err := q.WithContext(ctx).Where(q.SurveyID.Eq(surveyID)).Order(q.ID).Limit(limit).Pluck(q.ID, &ids)
+	return ids, err
+}
+
+// FindUnanonymized 按ID顺序查询问卷下未匿名化的答卷
+func (r *ResultRepo) FindUnanonymized(ctx context.Context, surveyID int64, limit int) ([]*model.Result, error) {
+	q := r.query.Result
+	return q.WithContext(ctx).Where(q.SurveyID.Eq(surveyID), q.AnonymizedAt.Eq(0)).Order(q.ID).Limit(limit).Find()
+}
+
+// Anonymize 清除答卷用户名并替换答卷内容
+func (r *ResultRepo) Anonymize(ctx context.Context, id int64, data string, anonymizedAt int64) error {
+	q := r.query.Result
+	_, err := q.WithContext(ctx).Where(q.ID.Eq(id)).UpdateSimple(
+		q.Username.Value(""),
+		q.Data.Value(data),
+		q.AnonymizedAt.Value(anonymizedAt),
+	)
+	return err
+}
+
+func (r *ResultRepo) DeleteByIDs(ctx context.Context, ids []int64) (int64, error) {
+	q := r.query.Result
+	result, err := q.WithContext(ctx).Where(q.ID.In(ids...)).Delete()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
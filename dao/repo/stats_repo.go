@@ -25,9 +25,10 @@ func NewStatsRepo(tx ...*query.Query) *StatsRepo {
 	}
 }
 
+// FindListBySurveyID 查询问卷统计数据 启用读写分离时读从库
 func (r *StatsRepo) FindListBySurveyID(ctx context.Context, surveyID int64) ([]*model.Stats, error) {
 	s := r.query.Stats
-	return s.WithContext(ctx).Where(s.SurveyID.Eq(surveyID)).Find()
+	return readReplica(ctx, s.WithContext(ctx)).Where(s.SurveyID.Eq(surveyID)).Find()
 }
 
 func (r *StatsRepo) BatchCreate(ctx context.Context, records []*model.Stats) error {
@@ -41,6 +42,11 @@ type StatsUpdate struct {
 }
 
 func (r *StatsRepo) BatchIncr(ctx context.Context, surveyID int64, updates []StatsUpdate) (int64, error) {
+	return r.BatchIncrBy(ctx, surveyID, updates, 1)
+}
+
+// BatchIncrBy 将各统计项数量增加delta 用于异步提交批量入库时合并更新
+func (r *StatsRepo) BatchIncrBy(ctx context.Context, surveyID int64, updates []StatsUpdate, delta int32) (int64, error) {
 	s := r.query.Stats
 	do := s.WithContext(ctx)
 	var conds query.IStatsDo
@@ -53,7 +59,27 @@ func (r *StatsRepo) BatchIncr(ctx context.Context, surveyID int64, updates []Sta
 		}
 	}
 	// WHERE survey_id = ? AND ((question_id= ? AND option_id= ?) OR (question_id= ? AND option_id = ?) ...)
-	res, err := do.Where(s.SurveyID.Eq(surveyID)).Where(conds).UpdateSimple(s.Count.Add(1))
+	res, err := do.Where(s.SurveyID.Eq(surveyID)).Where(conds).UpdateSimple(s.Count.Add(delta))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected, nil
+}
+
+// BatchDecr 扣减统计数据 与BatchIncr对应 数量不会扣减至负数
+func (r *StatsRepo) BatchDecr(ctx context.Context, surveyID int64, updates []StatsUpdate) (int64, error) {
+	s := r.query.Stats
+	do := s.WithContext(ctx)
+	var conds query.IStatsDo
+	for _, u := range updates {
+		condition := do.Where(s.QuestionID.Eq(u.QuestionID), s.OptionID.Eq(u.OptionID))
+		if conds == nil {
+			conds = condition
+		} else {
+			conds = conds.Or(condition)
+		}
+	}
+	res, err := do.Where(s.SurveyID.Eq(surveyID), s.Count.Gt(0)).Where(conds).UpdateSimple(s.Count.Sub(1))
 	if err != nil {
 		return 0, err
 	}
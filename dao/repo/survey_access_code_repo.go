@@ -0,0 +1,70 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/ndb"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+
+	"app/dao/model"
+	"app/dao/query"
+)
+
+type SurveyAccessCodeRepo struct {
+	query *query.Query
+}
+
+func NewSurveyAccessCodeRepo(tx ...*query.Query) *SurveyAccessCodeRepo {
+	var q *query.Query
+	if len(tx) > 0 {
+		q = tx[0]
+	} else {
+		q = query.Use(ndb.Pick())
+	}
+	return &SurveyAccessCodeRepo{
+		query: q,
+	}
+}
+
+func (r *SurveyAccessCodeRepo) FindByCode(ctx context.Context, surveyID int64, code string) (*model.SurveyAccessCode, error) {
+	c := r.query.SurveyAccessCode
+	record, err := c.WithContext(ctx).Where(c.SurveyID.Eq(surveyID), c.Code.Eq(code)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return record, err
+}
+
+func (r *SurveyAccessCodeRepo) FindInBatches(ctx context.Context, surveyID int64, batchSize int, fc func(list []*model.SurveyAccessCode) error) error {
+	c := r.query.SurveyAccessCode
+	var list []*model.SurveyAccessCode
+	return c.WithContext(ctx).Where(c.SurveyID.Eq(surveyID)).FindInBatches(&list, batchSize, func(_ gen.Dao, _ int) error {
+		return fc(list)
+	})
+}
+
+// BatchCreate 为问卷批量创建访问码
+func (r *SurveyAccessCodeRepo) BatchCreate(ctx context.Context, surveyID int64, codes []string) error {
+	c := r.query.SurveyAccessCode
+	return c.WithContext(ctx).CreateInBatches(lo.Map(codes, func(code string, _ int) *model.SurveyAccessCode {
+		return &model.SurveyAccessCode{
+			SurveyID: surveyID,
+			Code:     code,
+		}
+	}), 500)
+}
+
+// Consume 使用一次访问码并关联答卷 访问码不存在或已使用时影响行数为0
+func (r *SurveyAccessCodeRepo) Consume(ctx context.Context, surveyID int64, code string, resultID, usedAt int64) (int64, error) {
+	c := r.query.SurveyAccessCode
+	result, err := c.WithContext(ctx).
+		Where(c.SurveyID.Eq(surveyID), c.Code.Eq(code), c.UsedAt.Eq(0)).
+		UpdateSimple(c.ResultID.Value(resultID), c.UsedAt.Value(usedAt))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,127 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"github.com/zjutjh/mygo/ndb"
+	"gorm.io/gorm/clause"
+
+	"app/dao/model"
+	"app/dao/query"
+)
+
+type SurveyAllowlistRepo struct {
+	query *query.Query
+}
+
+func NewSurveyAllowlistRepo(tx ...*query.Query) *SurveyAllowlistRepo {
+	var q *query.Query
+	if len(tx) > 0 {
+		q = tx[0]
+	} else {
+		q = query.Use(ndb.Pick())
+	}
+	return &SurveyAllowlistRepo{
+		query: q,
+	}
+}
+
+// AllowlistEntry 答题名单条目
+type AllowlistEntry struct {
+	Username string
+	Remark   string
+}
+
+// AllowlistFilter 答题名单提交状态筛选
+type AllowlistFilter int8
+
+const (
+	AllowlistFilterAll       AllowlistFilter = 0 // 全部
+	AllowlistFilterSubmitted AllowlistFilter = 1 // 已提交
+	AllowlistFilterPending   AllowlistFilter = 2 // 未提交
+)
+
+func (r *SurveyAllowlistRepo) Exists(ctx context.Context, surveyID int64, username string) (bool, error) {
+	a := r.query.SurveyAllowlist
+	count, err := a.WithContext(ctx).Where(a.SurveyID.Eq(surveyID), a.Username.Eq(username)).Count()
+	return count > 0, err
+}
+
+func (r *SurveyAllowlistRepo) CountBySurveyID(ctx context.Context, surveyID int64) (int64, error) {
+	a := r.query.SurveyAllowlist
+	return a.WithContext(ctx).Where(a.SurveyID.Eq(surveyID)).Count()
+}
+
+// FindPage 分页查询答题名单 按用户在问卷下是否已有答卷筛选
+func (r *SurveyAllowlistRepo) FindPage(ctx context.Context, survey *model.Survey, filter AllowlistFilter, page, pageSize int) ([]*model.SurveyAllowlist, int64, error) {
+	a := r.query.SurveyAllowlist
+	do := a.WithContext(ctx).Where(a.SurveyID.Eq(survey.ID))
+
+	submitted := r.submittedUsernames(ctx, survey)
+	switch filter {
+	case AllowlistFilterSubmitted:
+		do = do.Where(a.Columns(a.Username).In(submitted))
+	case AllowlistFilterPending:
+		do = do.Where(a.Columns(a.Username).NotIn(submitted))
+	}
+
+	list, err := do.Order(a.ID).Limit(pageSize).Offset((page - 1) * pageSize).Find()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := do.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// CountProgress 统计答题名单总人数及已提交人数
+func (r *SurveyAllowlistRepo) CountProgress(ctx context.Context, survey *model.Survey) (total, submitted int64, err error) {
+	a := r.query.SurveyAllowlist
+	do := a.WithContext(ctx).Where(a.SurveyID.Eq(survey.ID))
+
+	total, err = do.Count()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	submitted, err = do.Where(a.Columns(a.Username).In(r.submittedUsernames(ctx, survey))).Count()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return total, submitted, nil
+}
+
+// submittedUsernames 问卷下已提交答卷的用户名子查询 按问卷归档状态选择答卷表
+func (r *SurveyAllowlistRepo) submittedUsernames(ctx context.Context, survey *model.Survey) query.IResultDo {
+	res := NewResultRepo(r.query).ForSurvey(survey).query.Result
+	return res.WithContext(ctx).Select(res.Username).Where(res.SurveyID.Eq(survey.ID))
+}
+
+// BatchSave 批量保存答题名单 用户名已存在时更新备注
+func (r *SurveyAllowlistRepo) BatchSave(ctx context.Context, surveyID int64, entries []AllowlistEntry) error {
+	a := r.query.SurveyAllowlist
+	return a.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{a.Remark.ColumnName().String()}),
+	}).CreateInBatches(lo.Map(entries, func(entry AllowlistEntry, _ int) *model.SurveyAllowlist {
+		return &model.SurveyAllowlist{
+			SurveyID: surveyID,
+			Username: entry.Username,
+			Remark:   entry.Remark,
+		}
+	}), 500)
+}
+
+func (r *SurveyAllowlistRepo) DeleteBySurveyID(ctx context.Context, surveyID int64) (int64, error) {
+	a := r.query.SurveyAllowlist
+	result, err := a.WithContext(ctx).Where(a.SurveyID.Eq(surveyID)).Delete()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zjutjh/mygo/ndb"
+	"gorm.io/gen"
 
 	"app/comm"
 	"app/dao/model"
@@ -44,9 +45,20 @@ func (r *SurveyRepo) FindByPath(ctx context.Context, path string) (*model.Survey
 	return record, nil
 }
 
+// FindAllPaths 查询全部问卷访问路径
+func (r *SurveyRepo) FindAllPaths(ctx context.Context) ([]string, error) {
+	s := r.query.Survey
+	var paths []string
+	if err := s.WithContext(ctx).Pluck(s.Path, &paths); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// FindPage 分页查询问卷列表 启用读写分离时读从库
 func (r *SurveyRepo) FindPage(ctx context.Context, page, pageSize int, adminID int64, surveyType comm.SurveyType, status comm.SurveyStatus, keyword string) ([]*model.Survey, int64, error) {
 	s := r.query.Survey
-	do := s.WithContext(ctx)
+	do := readReplica(ctx, s.WithContext(ctx))
 	if adminID > 0 {
 		do = do.Where(s.AdminID.Eq(adminID))
 	}
@@ -73,6 +85,33 @@ func (r *SurveyRepo) FindPage(ctx context.Context, page, pageSize int, adminID i
 	return list, total, nil
 }
 
+type AdminSurveyCount struct {
+	AdminID int64
+	Count   int64
+}
+
+func (r *SurveyRepo) CountGroupByAdminIDs(ctx context.Context, adminIDs []int64) ([]AdminSurveyCount, error) {
+	s := r.query.Survey
+	var list []AdminSurveyCount
+	err := s.WithContext(ctx).Select(s.AdminID, s.ID.Count().As("count")).
+		Where(s.AdminID.In(adminIDs...)).Group(s.AdminID).Scan(&list)
+	return list, err
+}
+
+func (r *SurveyRepo) FindListByIDs(ctx context.Context, ids []int64) ([]*model.Survey, error) {
+	s := r.query.Survey
+	return s.WithContext(ctx).Where(s.ID.In(ids...)).Find()
+}
+
+// FindAllInBatches 分批遍历全部问卷
+func (r *SurveyRepo) FindAllInBatches(ctx context.Context, batchSize int, fc func(list []*model.Survey) error) error {
+	s := r.query.Survey
+	var list []*model.Survey
+	return s.WithContext(ctx).FindInBatches(&list, batchSize, func(_ gen.Dao, _ int) error {
+		return fc(list)
+	})
+}
+
 func (r *SurveyRepo) Create(ctx context.Context, survey *model.Survey) error {
 	s := r.query.Survey
 	return s.WithContext(ctx).Create(survey)
@@ -96,6 +135,35 @@ func (r *SurveyRepo) UpdateStatus(ctx context.Context, id int64, status comm.Sur
 	return result.RowsAffected, nil
 }
 
+func (r *SurveyRepo) UpdateFrozenAt(ctx context.Context, id int64, frozenAt int64) (int64, error) {
+	s := r.query.Survey
+	result, err := s.WithContext(ctx).Where(s.ID.Eq(id)).UpdateSimple(s.FrozenAt.Value(frozenAt))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+// UpdateArchivingAt 标记问卷答卷归档或恢复开始
+func (r *SurveyRepo) UpdateArchivingAt(ctx context.Context, id int64, archivingAt int64) (int64, error) {
+	s := r.query.Survey
+	result, err := s.WithContext(ctx).Where(s.ID.Eq(id)).UpdateSimple(s.ArchivingAt.Value(archivingAt))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+// FinishArchiving 更新问卷答卷归档时间 同时清除归档中标记
+func (r *SurveyRepo) FinishArchiving(ctx context.Context, id int64, archivedAt int64) (int64, error) {
+	s := r.query.Survey
+	result, err := s.WithContext(ctx).Where(s.ID.Eq(id)).UpdateSimple(s.ArchivedAt.Value(archivedAt), s.ArchivingAt.Value(0))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
 func (r *SurveyRepo) DeleteByID(ctx context.Context, id int64) (int64, error) {
 	s := r.query.Survey
 	result, err := s.WithContext(ctx).Where(s.ID.Eq(id)).Delete()
@@ -104,3 +172,13 @@ func (r *SurveyRepo) DeleteByID(ctx context.Context, id int64) (int64, error) {
 	}
 	return result.RowsAffected, nil
 }
+
+// TransferAdmin 转移问卷所属管理员 包括已删除的问卷
+func (r *SurveyRepo) TransferAdmin(ctx context.Context, fromAdminID, toAdminID int64) (int64, error) {
+	s := r.query.Survey
+	result, err := s.WithContext(ctx).Unscoped().Where(s.AdminID.Eq(fromAdminID)).UpdateSimple(s.AdminID.Value(toAdminID))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
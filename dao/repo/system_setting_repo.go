@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zjutjh/mygo/ndb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"app/comm"
+	"app/dao/model"
+	"app/dao/query"
+)
+
+type SystemSettingRepo struct {
+	query *query.Query
+}
+
+func NewSystemSettingRepo(tx ...*query.Query) *SystemSettingRepo {
+	var q *query.Query
+	if len(tx) > 0 {
+		q = tx[0]
+	} else {
+		q = query.Use(ndb.Pick())
+	}
+	return &SystemSettingRepo{
+		query: q,
+	}
+}
+
+func (r *SystemSettingRepo) FindByName(ctx context.Context, name comm.SettingName) (*model.SystemSetting, error) {
+	s := r.query.SystemSetting
+	record, err := s.WithContext(ctx).Where(s.Name.Eq(string(name))).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return record, err
+}
+
+// Save 保存配置项 已存在时覆盖配置项值
+func (r *SystemSettingRepo) Save(ctx context.Context, name comm.SettingName, value string) error {
+	s := r.query.SystemSetting
+	return s.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{s.Value.ColumnName().String()}),
+	}).Create(&model.SystemSetting{
+		Name:  string(name),
+		Value: value,
+	})
+}
@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"app/comm"
+)
+
+const tracingSpanKey = "app:tracing_span"
+
+// TracingPlugin GORM链路追踪插件 为每条SQL创建Span 记录带占位符的SQL语句及影响行数
+type TracingPlugin struct{}
+
+func (TracingPlugin) Name() string {
+	return "app:tracing"
+}
+
+func (TracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	registers := []struct {
+		op     string
+		before func(name string, fn func(*gorm.DB)) error
+		after  func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+	for _, r := range registers {
+		if err := r.before("app:tracing_before_"+r.op, tracingBefore(r.op)); err != nil {
+			return err
+		}
+		if err := r.after("app:tracing_after_"+r.op, tracingAfter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func tracingBefore(op string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		parent, requestID := comm.SpanParent(db.Statement.Context)
+		_, span := comm.Tracer().Start(parent, "gorm."+op, trace.WithSpanKind(trace.SpanKindClient))
+		span.SetAttributes(
+			attribute.String("db.system", "mysql"),
+			attribute.String("db.sql.table", db.Statement.Table),
+		)
+		if requestID != "" {
+			span.SetAttributes(attribute.String("request_id", requestID))
+		}
+		db.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+func tracingAfter(db *gorm.DB) {
+	val, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := val.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	// 仅记录带占位符的SQL 不记录参数 避免答卷等数据写入链路
+	span.SetAttributes(
+		attribute.String("db.statement", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -1,12 +1,18 @@
 package repo
 
 import (
+	"time"
+
 	"github.com/zjutjh/mygo/ndb"
 
+	"app/comm"
 	"app/dao/query"
 )
 
 // Transaction 事务处理
 func Transaction(fc func(tx *query.Query) error) error {
-	return query.Use(ndb.Pick()).Transaction(fc)
+	start := time.Now()
+	err := query.Use(ndb.Pick()).Transaction(fc)
+	comm.ObserveTransaction(start, err)
+	return err
 }
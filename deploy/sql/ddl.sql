@@ -3,6 +3,8 @@ CREATE TABLE `admin` (
     `username` VARCHAR(16) NOT NULL COMMENT '用户名',
     `password` VARCHAR(255) NOT NULL COMMENT '密码',
     `type` TINYINT NOT NULL DEFAULT 1 COMMENT '类型 1-普通管理员 2-超级管理员',
+    `status` TINYINT NOT NULL DEFAULT 1 COMMENT '状态 1-正常 2-禁用',
+    `totp_secret` VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'TOTP密钥 为空表示未启用二次验证 配置加密密钥时加密存储',
     `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
     `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
     PRIMARY KEY (`id`),
@@ -13,10 +15,13 @@ CREATE TABLE `survey` (
     `id` BIGINT UNSIGNED AUTO_INCREMENT COMMENT '自增ID',
     `admin_id` BIGINT UNSIGNED NOT NULL COMMENT '所属管理员ID',
     `title` VARCHAR(64) NOT NULL COMMENT '标题',
-    `type` TINYINT NOT NULL COMMENT '类型 1-问卷 2-投票',
+    `type` TINYINT NOT NULL COMMENT '类型 1-问卷 2-投票 3-测验',
     `path` VARCHAR(64) NOT NULL COMMENT '访问路径',
     `schema` JSON NOT NULL COMMENT '结构',
     `status` TINYINT NOT NULL DEFAULT 1 COMMENT '状态 1-未发布 2-已发布',
+    `frozen_at` BIGINT NOT NULL DEFAULT 0 COMMENT '暂停提交时间 0表示未暂停',
+    `archived_at` BIGINT NOT NULL DEFAULT 0 COMMENT '答卷归档时间 0表示未归档',
+    `archiving_at` BIGINT NOT NULL DEFAULT 0 COMMENT '答卷归档或恢复开始时间 0表示未在迁移',
     `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
     `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
     `deleted_at` BIGINT NOT NULL DEFAULT 0 COMMENT '删除时间 (软删除)',
@@ -29,12 +34,32 @@ CREATE TABLE `result` (
     `survey_id` BIGINT UNSIGNED NOT NULL COMMENT '问卷ID',
     `username` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '用户名',
     `data` JSON NOT NULL COMMENT '答卷内容',
+    `score` INT NOT NULL DEFAULT 0 COMMENT '得分 (测验)',
+    `anonymized_at` BIGINT NOT NULL DEFAULT 0 COMMENT '匿名化时间 0表示未匿名化',
+    `ticket` VARCHAR(36) DEFAULT NULL COMMENT '异步提交凭证 同步提交时为空',
     `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
     `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
     PRIMARY KEY (`id`),
-    INDEX `idx_survey_id_username_created_at` (`survey_id`, `username`, `created_at`)
+    UNIQUE KEY `uk_ticket` (`ticket`),
+    INDEX `idx_survey_id_username_created_at` (`survey_id`, `username`, `created_at`),
+    INDEX `idx_username` (`username`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='答卷表';
 
+CREATE TABLE `result_archive` (
+    `id` BIGINT UNSIGNED NOT NULL COMMENT '答卷ID 与归档前一致',
+    `survey_id` BIGINT UNSIGNED NOT NULL COMMENT '问卷ID',
+    `username` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '用户名',
+    `data` JSON NOT NULL COMMENT '答卷内容',
+    `score` INT NOT NULL DEFAULT 0 COMMENT '得分 (测验)',
+    `anonymized_at` BIGINT NOT NULL DEFAULT 0 COMMENT '匿名化时间 0表示未匿名化',
+    `ticket` VARCHAR(36) DEFAULT NULL COMMENT '异步提交凭证 同步提交时为空',
+    `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+    `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    INDEX `idx_survey_id_username_created_at` (`survey_id`, `username`, `created_at`),
+    INDEX `idx_username` (`username`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='答卷归档表 已结束问卷的答卷 结构与答卷表一致';
+
 CREATE TABLE `stats` (
     `id` BIGINT UNSIGNED AUTO_INCREMENT COMMENT '自增ID',
     `survey_id` BIGINT UNSIGNED NOT NULL COMMENT '问卷ID',
@@ -45,4 +70,114 @@ CREATE TABLE `stats` (
     `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
     PRIMARY KEY (`id`),
     UNIQUE KEY `uk_survey_id_question_id_option_id` (`survey_id`, `question_id`, `option_id`)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='统计表';
\ No newline at end of file
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='统计表';
+
+CREATE TABLE `cascade_dataset` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT COMMENT '自增ID',
+    `admin_id` BIGINT UNSIGNED NOT NULL COMMENT '创建管理员ID',
+    `name` VARCHAR(64) NOT NULL COMMENT '名称',
+    `data` JSON NOT NULL COMMENT '级联选项树',
+    `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+    `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='级联数据集表';
+
+CREATE TABLE `question_bank` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT COMMENT '自增ID',
+    `admin_id` BIGINT UNSIGNED NOT NULL COMMENT '创建管理员ID',
+    `scope` TINYINT NOT NULL DEFAULT 1 COMMENT '范围 1-私有 2-共享',
+    `title` VARCHAR(255) NOT NULL COMMENT '题目标题',
+    `type` VARCHAR(16) NOT NULL COMMENT '题型',
+    `tags` JSON NOT NULL COMMENT '标签列表',
+    `data` JSON NOT NULL COMMENT '题目结构',
+    `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+    `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    INDEX `idx_scope_admin_id` (`scope`, `admin_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='题库表';
+
+CREATE TABLE `admin_invite` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT COMMENT '自增ID',
+    `code` VARCHAR(32) NOT NULL COMMENT '邀请码',
+    `creator_id` BIGINT UNSIGNED NOT NULL COMMENT '创建管理员ID',
+    `username` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '预分配用户名 为空表示不限',
+    `max_uses` INT NOT NULL COMMENT '可使用次数',
+    `used_count` INT NOT NULL DEFAULT 0 COMMENT '已使用次数',
+    `expire_at` TIMESTAMP(3) NOT NULL COMMENT '过期时间',
+    `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+    `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='管理员邀请码表';
+
+CREATE TABLE `admin_invite_use` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT COMMENT '自增ID',
+    `invite_id` BIGINT UNSIGNED NOT NULL COMMENT '邀请码ID',
+    `admin_id` BIGINT UNSIGNED NOT NULL COMMENT '创建的管理员ID',
+    `username` VARCHAR(16) NOT NULL COMMENT '创建的管理员用户名',
+    `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+    `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    INDEX `idx_invite_id` (`invite_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='管理员邀请码使用记录表';
+
+CREATE TABLE `audit_log` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT COMMENT '自增ID',
+    `admin_id` BIGINT UNSIGNED NOT NULL COMMENT '操作管理员ID',
+    `action` VARCHAR(32) NOT NULL COMMENT '操作',
+    `target_type` VARCHAR(16) NOT NULL COMMENT '操作对象类型',
+    `target_id` BIGINT UNSIGNED NOT NULL COMMENT '操作对象ID',
+    `detail` JSON NOT NULL COMMENT '操作详情',
+    `ip` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '客户端IP',
+    `request_id` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '请求ID',
+    `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+    `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    INDEX `idx_admin_id_created_at` (`admin_id`, `created_at`),
+    INDEX `idx_target_type_target_id` (`target_type`, `target_id`),
+    INDEX `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='审计日志表';
+
+CREATE TABLE `admin_recovery_code` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT COMMENT '自增ID',
+    `admin_id` BIGINT UNSIGNED NOT NULL COMMENT '管理员ID',
+    `code_hash` CHAR(64) NOT NULL COMMENT '恢复码哈希',
+    `used_at` BIGINT NOT NULL DEFAULT 0 COMMENT '使用时间 0表示未使用',
+    `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+    `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    INDEX `idx_admin_id_code_hash` (`admin_id`, `code_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='管理员二次验证恢复码表';
+
+CREATE TABLE `system_setting` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT COMMENT '自增ID',
+    `name` VARCHAR(64) NOT NULL COMMENT '配置项名称',
+    `value` VARCHAR(255) NOT NULL COMMENT '配置项值',
+    `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+    `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='系统运行时配置表';
+
+CREATE TABLE `survey_access_code` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT COMMENT '自增ID',
+    `survey_id` BIGINT UNSIGNED NOT NULL COMMENT '问卷ID',
+    `code` VARCHAR(16) NOT NULL COMMENT '访问码',
+    `result_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '使用该访问码提交的答卷ID 0表示未使用',
+    `used_at` BIGINT NOT NULL DEFAULT 0 COMMENT '使用时间 0表示未使用',
+    `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+    `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_survey_id_code` (`survey_id`, `code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='问卷一次性访问码表';
+
+CREATE TABLE `survey_allowlist` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT COMMENT '自增ID',
+    `survey_id` BIGINT UNSIGNED NOT NULL COMMENT '问卷ID',
+    `username` VARCHAR(16) NOT NULL COMMENT '用户名',
+    `remark` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '备注 如姓名、班级',
+    `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+    `updated_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_survey_id_username` (`survey_id`, `username`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='问卷答题名单表';
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/repo"
+)
+
+// AdminActive 校验已登录管理员的账号状态 禁用或已删除的管理员持有的Token立即失效
+// 需挂载在管理员鉴权中间件之后 未登录时直接放行
+func AdminActive() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		// 查询账号状态缓存
+		status, err := cache.NewAdminStatusCache().Get(ctx, admin.ID)
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员状态缓存失败")
+		}
+		// 缓存未命中 回源数据库
+		if status == nil {
+			record, err := repo.NewAdminRepo().FindByID(ctx, admin.ID)
+			if err != nil {
+				nlog.Pick().WithContext(ctx).WithError(err).Error("查询管理员失败")
+				reply.Fail(ctx, comm.CodeDatabaseError)
+				return
+			}
+			s := comm.AdminStatus(0)
+			if record != nil {
+				s = comm.AdminStatus(record.Status)
+			}
+			status = &s
+
+			// 设置账号状态缓存
+			if err := cache.NewAdminStatusCache().Set(ctx, admin.ID, s); err != nil {
+				nlog.Pick().WithContext(ctx).WithError(err).Error("设置管理员状态缓存失败")
+			}
+		}
+
+		switch *status {
+		case comm.AdminStatusNormal:
+			ctx.Next()
+		case comm.AdminStatusDisabled:
+			reply.Fail(ctx, comm.CodeAdminDisabled)
+		default:
+			reply.Fail(ctx, comm.CodeLoginExpired)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/repo"
+)
+
+// AdminTwoFactor 强制二次验证开启时 拦截未通过二次验证的管理员会话
+// 需挂载在管理员鉴权中间件之后 未登录时直接放行
+func AdminTwoFactor() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+		if err != nil || admin.TwoFactor {
+			ctx.Next()
+			return
+		}
+
+		// 查询强制二次验证配置缓存
+		enforced, err := cache.NewSettingCache().Get(ctx, comm.SettingTotpEnforced)
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询系统配置缓存失败")
+		}
+		// 缓存未命中 回源数据库
+		if enforced == nil {
+			setting, err := repo.NewSystemSettingRepo().FindByName(ctx, comm.SettingTotpEnforced)
+			if err != nil {
+				nlog.Pick().WithContext(ctx).WithError(err).Error("查询系统配置失败")
+				reply.Fail(ctx, comm.CodeDatabaseError)
+				return
+			}
+			value := ""
+			if setting != nil {
+				value = setting.Value
+			}
+			enforced = &value
+
+			// 设置系统配置缓存
+			if err := cache.NewSettingCache().Set(ctx, comm.SettingTotpEnforced, value); err != nil {
+				nlog.Pick().WithContext(ctx).WithError(err).Error("设置系统配置缓存失败")
+			}
+		}
+
+		if *enforced == "true" {
+			reply.Fail(ctx, comm.CodeTotpSetupRequired)
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/comm"
+	"app/dao/cache"
+)
+
+// Maintenance 维护模式开启时拒绝写请求 GET请求直接放行
+// 仅挂载在写接口所在的分组或路由上 登录相关接口及维护开关接口不挂载 保证维护期间可登录并关闭维护模式
+func Maintenance() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method == http.MethodGet || ctx.Request.Method == http.MethodHead {
+			ctx.Next()
+			return
+		}
+		checkMaintenance(ctx)
+	}
+}
+
+// MaintenanceAlways 维护模式开启时拒绝请求 不区分请求方法
+// 用于记录审计日志的GET接口 (如导出) 审计日志写入失败时不返回内容 维护期间无法保证写入
+func MaintenanceAlways() gin.HandlerFunc {
+	return checkMaintenance
+}
+
+func checkMaintenance(ctx *gin.Context) {
+	since, err := cache.NewMaintenanceCache().Get(ctx)
+	if err != nil {
+		// 无法确认时放行 Redis不可用时写接口自身会返回错误
+		nlog.Pick().WithContext(ctx).WithError(err).Error("查询维护模式失败")
+	}
+	if since != nil {
+		reply.Fail(ctx, comm.CodeServiceMaintenance)
+		return
+	}
+	ctx.Next()
+}
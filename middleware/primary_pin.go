@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/comm"
+	"app/dao/cache"
+	"app/dao/repo"
+)
+
+// AdminPrimaryPin 管理员写操作后的读主库窗口内 固定当前请求的查询使用主库
+// 需挂载在管理员鉴权中间件之后 未启用读写分离或未登录时直接放行
+func AdminPrimaryPin() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !comm.BizConf.DBReplica.Enable() {
+			ctx.Next()
+			return
+		}
+		admin, err := jwt.GetIdentity[comm.AdminIdentity](ctx)
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		pinned, err := cache.NewPrimaryPinCache().Exists(ctx, admin.ID)
+		if err != nil {
+			// 无法确认时读主库 保证一致性
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询读主库标记失败")
+			pinned = true
+		}
+		if pinned {
+			repo.PinPrimary(ctx)
+		}
+		ctx.Next()
+	}
+}
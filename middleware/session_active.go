@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/jwt"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/comm"
+	"app/dao/cache"
+)
+
+// SessionActive 校验访问Token是否已被吊销 已登出或主体全部会话被吊销时Token立即失效
+// 需挂载在对应鉴权中间件之后 未登录时直接放行
+func SessionActive[T comm.SessionIdentity](scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		identity, err := jwt.GetIdentity[T](ctx)
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		// 不含会话信息的Token签发于支持吊销之前 要求重新登录
+		session := identity.Session()
+		if session.TokenID == "" {
+			reply.Fail(ctx, comm.CodeLoginExpired)
+			return
+		}
+
+		active, err := cache.NewSessionCache(scope).Active(ctx, identity.Subject(), session.TokenID, session.IssuedAt)
+		if err != nil {
+			nlog.Pick().WithContext(ctx).WithError(err).Error("查询会话吊销状态失败")
+			reply.Fail(ctx, comm.CodeRedisError)
+			return
+		}
+		if !active {
+			reply.Fail(ctx, comm.CodeLoginExpired)
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceRequestID 将请求ID写入HTTP请求的Span 并在响应头中返回TraceID 便于按请求ID或TraceID互查
+// 需挂载在otelgin中间件之后
+func TraceRequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		span := trace.SpanFromContext(ctx.Request.Context())
+		if span.SpanContext().IsValid() {
+			span.SetAttributes(attribute.String("request_id", requestid.Get(ctx)))
+			ctx.Header("X-Trace-ID", span.SpanContext().TraceID().String())
+		}
+		ctx.Next()
+	}
+}
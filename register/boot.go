@@ -1,7 +1,9 @@
 package register
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/zjutjh/mygo/config"
 	"github.com/zjutjh/mygo/feishu"
@@ -12,8 +14,19 @@ import (
 	"github.com/zjutjh/mygo/nedis"
 	"github.com/zjutjh/mygo/nesty"
 	"github.com/zjutjh/mygo/nlog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 
 	"app/comm"
+	"app/dao/cache"
+	"app/dao/repo"
 	"app/register/generate"
 )
 
@@ -34,6 +47,8 @@ func Boot() kernel.BootList {
 
 		// 业务引导器
 		BizConfBoot(),
+		TracingBoot(),
+		DBReplicaBoot(),
 		AppBoot(),
 	}
 }
@@ -41,11 +56,107 @@ func Boot() kernel.BootList {
 // BizConfBoot 初始化应用业务配置引导器
 func BizConfBoot() func() error {
 	return func() error {
-		comm.BizConf = &comm.BizConfig{}
+		comm.BizConf = &comm.BizConfig{
+			LoginGuard: comm.LoginGuardConfig{
+				FreeFails:    2,
+				MaxDelay:     30 * time.Second,
+				UserMaxFails: 5,
+				IPMaxFails:   30,
+				Window:       15 * time.Minute,
+				LockDuration: 15 * time.Minute,
+			},
+			Session: comm.SessionConfig{
+				AdminRefreshExpiration: 168 * time.Hour,
+				UserRefreshExpiration:  72 * time.Hour,
+			},
+			SurveyCache: comm.SurveyCacheConfig{
+				NegativeTTL: 30 * time.Second,
+				PathFilter:  true,
+			},
+			Tracing: comm.TracingConfig{
+				Endpoint:    "localhost:4318",
+				Insecure:    true,
+				SampleRatio: 0.1,
+			},
+			DBReplica: comm.DBReplicaConfig{
+				PinWindow: 5 * time.Second,
+			},
+		}
 		err := config.Pick().UnmarshalKey("biz", comm.BizConf)
 		if err != nil {
 			return fmt.Errorf("%w: 解析应用业务配置错误: %w", kit.ErrDataUnmarshal, err)
 		}
+
+		comm.AnswerKeyring, err = comm.NewKeyring(comm.BizConf.Crypto)
+		if err != nil {
+			return fmt.Errorf("%w: 初始化敏感答案加密密钥错误: %w", kit.ErrDataFormat, err)
+		}
+		return nil
+	}
+}
+
+// TracingBoot 初始化链路追踪引导器 未启用时不上报 为DB及Redis客户端挂载链路追踪
+func TracingBoot() func() error {
+	return func() error {
+		conf := comm.BizConf.Tracing
+		if !conf.Enable {
+			return nil
+		}
+
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(conf.Endpoint)}
+		if conf.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(context.Background(), opts...)
+		if err != nil {
+			return fmt.Errorf("%w: 初始化链路追踪上报错误: %w", kit.ErrDataFormat, err)
+		}
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SampleRatio))),
+			sdktrace.WithResource(resource.NewSchemaless(
+				attribute.String("service.name", config.AppName()),
+				attribute.String("deployment.environment", config.AppEnv()),
+			)),
+		))
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+		if err := ndb.Pick().Use(repo.TracingPlugin{}); err != nil {
+			return fmt.Errorf("%w: 注册DB链路追踪插件错误: %w", kit.ErrDataFormat, err)
+		}
+		nedis.Pick().AddHook(cache.TracingHook{})
+		return nil
+	}
+}
+
+// DBReplicaBoot 初始化数据库读写分离引导器 从库以独立DB实例加载 注册为具名解析器
+// 未显式指定从库的读写均使用主库 避免读己之写不一致
+func DBReplicaBoot() func() error {
+	return func() error {
+		conf := comm.BizConf.DBReplica
+		if !conf.Enable() {
+			return nil
+		}
+
+		replicas := make([]gorm.Dialector, 0, len(conf.Scopes))
+		for _, scope := range conf.Scopes {
+			if err := ndb.Boot(scope)(); err != nil {
+				return err
+			}
+			conn, err := ndb.Pick(scope).DB()
+			if err != nil {
+				return fmt.Errorf("%w: 获取从库[%s]连接错误: %w", kit.ErrDataFormat, scope, err)
+			}
+			replicas = append(replicas, mysql.New(mysql.Config{Conn: conn}))
+		}
+
+		resolver := dbresolver.Register(dbresolver.Config{
+			Replicas: replicas,
+			Policy:   dbresolver.RandomPolicy{},
+		}, repo.ReplicaResolver)
+		if err := ndb.Pick().Use(resolver); err != nil {
+			return fmt.Errorf("%w: 注册DB读写分离插件错误: %w", kit.ErrDataFormat, err)
+		}
 		return nil
 	}
 }
@@ -54,6 +165,9 @@ func BizConfBoot() func() error {
 func AppBoot() func() error {
 	return func() error {
 		// 可以在这里编写业务初始引导逻辑
+
+		// Redis命令监控
+		nedis.Pick().AddHook(cache.MetricsHook{})
 		return nil
 	}
 }
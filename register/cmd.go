@@ -5,6 +5,8 @@ import (
 	"github.com/zjutjh/mygo/foundation/command"
 	"github.com/zjutjh/mygo/foundation/crontab"
 	"github.com/zjutjh/mygo/foundation/httpserver"
+
+	"app/cmd"
 )
 
 func Command(root *cobra.Command) {
@@ -13,5 +15,9 @@ func Command(root *cobra.Command) {
 	command.Add("cron", crontab.CommandRegister(Cron))
 
 	// 业务命令
-	//command.Add("xxx", cmd.XXXRun)
+	command.Add("rekey", cmd.RekeyRun)                    // 使用当前密钥重新加密敏感答案及TOTP密钥
+	command.Add("submit-worker", cmd.SubmitWorkerRun)     // 消费异步提交队列 将答卷批量入库
+	command.Add("archive", cmd.ArchiveRun)                // 将指定问卷的答卷移入归档表
+	command.Add("archive-restore", cmd.ArchiveRestoreRun) // 将指定问卷的答卷移回答卷表
+	command.Add("maintenance", cmd.MaintenanceRun)        // 开启/关闭/查询全局维护模式
 }
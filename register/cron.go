@@ -2,11 +2,15 @@ package register
 
 import (
 	cron2 "github.com/robfig/cron/v3"
+
+	"app/cron"
 )
 
 func CronWithHTTPServer(c *cron2.Cron) {
 	// 定时任务 (默认随HTTP Server伴生运行)
 	// c.AddJob("* * * * * *", cron.XXXJob{})
+	_, _ = c.AddJob("0 30 3 * * *", cron.RetentionJob{}) // 答卷保留策略 每日03:30执行
+	_, _ = c.AddJob("0 30 4 * * *", cron.ArchiveJob{})   // 答卷归档 每日04:30执行
 }
 
 func Cron(c *cron2.Cron) {
@@ -1,21 +1,34 @@
 package register
 
 import (
+	"context"
 	"slices"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/zjutjh/mygo/config"
 	midjwt "github.com/zjutjh/mygo/jwt/middleware"
 	"github.com/zjutjh/mygo/middleware/cors"
 	"github.com/zjutjh/mygo/swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	"app/api"
+	adminaccount "app/api/admin/account"
+	adminaudit "app/api/admin/audit"
 	adminauth "app/api/admin/auth"
+	adminbank "app/api/admin/bank"
+	admindataset "app/api/admin/dataset"
+	admininvite "app/api/admin/invite"
+	adminprivacy "app/api/admin/privacy"
 	adminresult "app/api/admin/result"
 	adminsurvey "app/api/admin/survey"
+	adminsystem "app/api/admin/system"
 	userauth "app/api/user/auth"
 	usersurvey "app/api/user/survey"
 	"app/comm"
+	"app/dao/cache"
+	"app/dao/repo"
+	"app/middleware"
 )
 
 var (
@@ -23,13 +36,42 @@ var (
 	adminAuthRequired = midjwt.Auth[comm.AdminIdentity](true, "jwt_admin")
 	adminAuthOptional = midjwt.Auth[comm.AdminIdentity](false, "jwt_admin")
 
+	// 管理员会话吊销校验中间件 需挂载在管理员鉴权中间件之后
+	adminSession = middleware.SessionActive[comm.AdminIdentity](cache.SessionScopeAdmin)
+
+	// 管理员账号状态校验中间件 需挂载在管理员鉴权中间件之后
+	adminActive = middleware.AdminActive()
+
+	// 管理员强制二次验证中间件 需挂载在管理员鉴权中间件之后
+	adminTwoFactor = middleware.AdminTwoFactor()
+
+	// 管理员写后读主库中间件 需挂载在管理员鉴权中间件之后 仅用于包含从库查询的接口
+	adminPrimaryPin = middleware.AdminPrimaryPin()
+
+	// 维护模式中间件 维护期间拒绝写请求 登录相关接口及维护开关接口不挂载
+	// 记录审计日志的读接口视为写请求 POST接口 (查看敏感信息) 由maintenance拒绝 GET接口 (导出) 额外挂载maintenanceAudit
+	maintenance      = middleware.Maintenance()
+	maintenanceAudit = middleware.MaintenanceAlways()
+
 	// 用户鉴权中间件
 	userAuthRequired = midjwt.Auth[comm.UserIdentity](true, "jwt_user")
 	userAuthOptional = midjwt.Auth[comm.UserIdentity](false, "jwt_user")
+
+	// 用户会话吊销校验中间件 需挂载在用户鉴权中间件之后
+	userSession = middleware.SessionActive[comm.UserIdentity](cache.SessionScopeUser)
 )
 
 func Route(router *gin.Engine) {
+	// 订阅问卷缓存失效及新建问卷通知 并构建问卷访问路径过滤器 仅HTTP服务使用 命令行不启动
+	go cache.ListenSurveyInvalidate(context.Background(), repo.NewSurveyRepo().FindAllPaths)
+
 	router.Use(cors.Pick())
+	if comm.BizConf.Metrics.Enable {
+		router.Use(middleware.Metrics())
+	}
+	if comm.BizConf.Tracing.Enable {
+		router.Use(otelgin.Middleware(config.AppName()), middleware.TraceRequestID())
+	}
 
 	r := router.Group(routePrefix())
 	{
@@ -40,23 +82,87 @@ func Route(router *gin.Engine) {
 		{
 			authGroup := adminGroup.Group("/auth")
 			{
-				authGroup.GET("/info", adminAuthRequired, adminauth.InfoHandler())      // 获取管理员信息
-				authGroup.POST("/create", adminAuthOptional, adminauth.CreateHandler()) // 创建管理员
-				authGroup.POST("/login", adminauth.LoginHandler())                      // 管理员登录
+				authGroup.GET("/info", adminAuthRequired, adminSession, adminActive, adminauth.InfoHandler())                      // 获取管理员信息
+				authGroup.POST("/create", adminAuthOptional, adminSession, adminActive, adminTwoFactor, adminauth.CreateHandler()) // 创建管理员
+				authGroup.POST("/login", adminauth.LoginHandler())                                                                 // 管理员登录
+				authGroup.POST("/refresh", adminauth.RefreshHandler())                                                             // 刷新管理员Token
+				authGroup.POST("/logout", adminAuthRequired, adminSession, adminauth.LogoutHandler())                              // 管理员登出
+				authGroup.POST("/password", adminAuthRequired, adminSession, adminActive, adminauth.PasswordHandler())             // 修改密码
+			}
+			totpGroup := adminGroup.Group("/auth/totp", adminAuthRequired, adminSession, adminActive)
+			{
+				totpGroup.POST("/setup", adminauth.TotpSetupHandler())       // 获取二次验证密钥
+				totpGroup.POST("/enable", adminauth.TotpEnableHandler())     // 启用二次验证
+				totpGroup.POST("/disable", adminauth.TotpDisableHandler())   // 停用二次验证
+				totpGroup.POST("/recovery", adminauth.TotpRecoveryHandler()) // 重新生成恢复码
 			}
-			surveyGroup := adminGroup.Group("/survey", adminAuthRequired)
+			accountGroup := adminGroup.Group("/account", adminAuthRequired, adminSession, adminActive, adminTwoFactor, maintenance)
 			{
-				surveyGroup.GET("/detail", adminsurvey.DetailHandler())  // 获取问卷详情
-				surveyGroup.GET("/list", adminsurvey.ListHandler())      // 获取问卷列表
-				surveyGroup.POST("/create", adminsurvey.CreateHandler()) // 创建问卷
-				surveyGroup.POST("/update", adminsurvey.UpdateHandler()) // 更新问卷
-				surveyGroup.POST("/status", adminsurvey.StatusHandler()) // 修改问卷状态
-				surveyGroup.POST("/delete", adminsurvey.DeleteHandler()) // 删除问卷
+				accountGroup.GET("/list", adminaccount.ListHandler())          // 获取管理员列表
+				accountGroup.POST("/status", adminaccount.StatusHandler())     // 修改管理员状态
+				accountGroup.POST("/password", adminaccount.PasswordHandler()) // 重置管理员密码
+				accountGroup.POST("/delete", adminaccount.DeleteHandler())     // 删除管理员
+				accountGroup.POST("/revoke", adminaccount.RevokeHandler())     // 吊销全部会话
+				accountGroup.POST("/enforce", adminaccount.EnforceHandler())   // 设置强制二次验证
 			}
-			resultGroup := adminGroup.Group("/result", adminAuthRequired)
+			privacyGroup := adminGroup.Group("/privacy", adminAuthRequired, adminSession, adminActive, adminTwoFactor, maintenance)
 			{
-				resultGroup.GET("/stats", adminresult.StatsHandler()) // 获取答卷统计数据
-				resultGroup.GET("/list", adminresult.ListHandler())   // 获取答卷列表
+				privacyGroup.GET("/find", adminprivacy.FindHandler())                       // 查找用户答卷
+				privacyGroup.GET("/export", maintenanceAudit, adminprivacy.ExportHandler()) // 导出用户答卷
+				privacyGroup.POST("/erase", adminprivacy.EraseHandler())                    // 删除用户答卷
+			}
+			auditGroup := adminGroup.Group("/audit", adminAuthRequired, adminSession, adminActive, adminTwoFactor)
+			{
+				auditGroup.GET("/list", adminaudit.ListHandler()) // 获取审计日志列表
+			}
+			inviteGroup := adminGroup.Group("/invite", adminAuthRequired, adminSession, adminActive, adminTwoFactor, maintenance)
+			{
+				inviteGroup.GET("/list", admininvite.ListHandler())      // 获取邀请码列表
+				inviteGroup.POST("/create", admininvite.CreateHandler()) // 生成邀请码
+				inviteGroup.POST("/expire", admininvite.ExpireHandler()) // 作废邀请码
+			}
+			surveyGroup := adminGroup.Group("/survey", adminAuthRequired, adminSession, adminActive, adminTwoFactor, adminPrimaryPin, maintenance)
+			{
+				surveyGroup.GET("/detail", adminsurvey.DetailHandler())                            // 获取问卷详情
+				surveyGroup.GET("/list", adminsurvey.ListHandler())                                // 获取问卷列表
+				surveyGroup.POST("/create", adminsurvey.CreateHandler())                           // 创建问卷
+				surveyGroup.POST("/update", adminsurvey.UpdateHandler())                           // 更新问卷
+				surveyGroup.POST("/status", adminsurvey.StatusHandler())                           // 修改问卷状态
+				surveyGroup.POST("/delete", adminsurvey.DeleteHandler())                           // 删除问卷
+				surveyGroup.POST("/freeze", adminsurvey.FreezeHandler())                           // 暂停/恢复问卷提交
+				surveyGroup.POST("/code/create", adminsurvey.CodeCreateHandler())                  // 生成问卷访问码
+				surveyGroup.GET("/code/export", maintenanceAudit, adminsurvey.CodeExportHandler()) // 导出问卷访问码
+				surveyGroup.POST("/allowlist/save", adminsurvey.AllowlistSaveHandler())            // 保存问卷答题名单
+				surveyGroup.GET("/allowlist/progress", adminsurvey.AllowlistProgressHandler())     // 获取答题名单完成情况
+			}
+			resultGroup := adminGroup.Group("/result", adminAuthRequired, adminSession, adminActive, adminTwoFactor, adminPrimaryPin, maintenance)
+			{
+				resultGroup.GET("/stats", adminresult.StatsHandler())                     // 获取答卷统计数据
+				resultGroup.GET("/list", adminresult.ListHandler())                       // 获取答卷列表
+				resultGroup.GET("/score", adminresult.ScoreHandler())                     // 获取测验成绩报告
+				resultGroup.GET("/export", maintenanceAudit, adminresult.ExportHandler()) // 导出答卷
+				resultGroup.POST("/reveal", adminresult.RevealHandler())                  // 查看答卷敏感信息
+			}
+			datasetGroup := adminGroup.Group("/dataset", adminAuthRequired, adminSession, adminActive, adminTwoFactor, maintenance)
+			{
+				datasetGroup.GET("/detail", admindataset.DetailHandler())  // 获取级联数据集详情
+				datasetGroup.GET("/list", admindataset.ListHandler())      // 获取级联数据集列表
+				datasetGroup.POST("/create", admindataset.CreateHandler()) // 创建级联数据集
+				datasetGroup.POST("/update", admindataset.UpdateHandler()) // 更新级联数据集
+				datasetGroup.POST("/delete", admindataset.DeleteHandler()) // 删除级联数据集
+			}
+			bankGroup := adminGroup.Group("/bank", adminAuthRequired, adminSession, adminActive, adminTwoFactor, maintenance)
+			{
+				bankGroup.GET("/list", adminbank.ListHandler())      // 获取题库题目列表
+				bankGroup.POST("/save", adminbank.SaveHandler())     // 保存题目到题库
+				bankGroup.POST("/tag", adminbank.TagHandler())       // 设置题库题目标签
+				bankGroup.POST("/delete", adminbank.DeleteHandler()) // 删除题库题目
+				bankGroup.POST("/insert", adminbank.InsertHandler()) // 插入题库题目
+			}
+			systemGroup := adminGroup.Group("/system", adminAuthRequired, adminSession, adminActive, adminTwoFactor)
+			{
+				systemGroup.GET("/maintenance", adminsystem.MaintenanceStatusHandler()) // 获取维护模式状态
+				systemGroup.POST("/maintenance", adminsystem.MaintenanceHandler())      // 设置维护模式
 			}
 		}
 
@@ -64,13 +170,18 @@ func Route(router *gin.Engine) {
 		{
 			authGroup := userGroup.Group("/auth")
 			{
-				authGroup.GET("/info", userAuthRequired, userauth.InfoHandler()) // 获取用户信息
-				authGroup.POST("/login", userauth.LoginHandler())                // 用户登录
+				authGroup.GET("/info", userAuthRequired, userSession, userauth.InfoHandler())      // 获取用户信息
+				authGroup.POST("/login", userauth.LoginHandler())                                  // 用户登录
+				authGroup.POST("/refresh", userauth.RefreshHandler())                              // 刷新用户Token
+				authGroup.POST("/logout", userAuthRequired, userSession, userauth.LogoutHandler()) // 用户登出
 			}
+			// 会话吊销校验仅用于提交 避免高频读接口每次请求访问Redis
 			surveyGroup := userGroup.Group("/survey", userAuthOptional)
 			{
-				surveyGroup.GET("/detail", usersurvey.DetailHandler())  // 获取问卷详情
-				surveyGroup.POST("/submit", usersurvey.SubmitHandler()) // 提交问卷
+				surveyGroup.GET("/detail", usersurvey.DetailHandler())                            // 获取问卷详情
+				surveyGroup.POST("/submit", userSession, maintenance, usersurvey.SubmitHandler()) // 提交问卷
+				surveyGroup.POST("/render", usersurvey.RenderHandler())                           // 渲染答案引用
+				surveyGroup.GET("/ticket", usersurvey.TicketHandler())                            // 查询异步提交状态
 			}
 		}
 	}
@@ -86,8 +197,15 @@ func routeBase(r *gin.RouterGroup, router *gin.Engine) {
 		r.GET("/swagger.json", swagger.DocumentHandler(router))
 	}
 
+	// Prometheus 监控指标
+	if comm.BizConf.Metrics.Enable {
+		router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	}
+
 	// 健康检查
 	r.GET("/health", api.HealthHandler())
+	r.GET("/health/live", api.HealthLiveHandler())   // 存活探针 不检查外部依赖
+	r.GET("/health/ready", api.HealthReadyHandler()) // 就绪探针 关键依赖不可用时返回503
 }
 
 func init() {
@@ -7,15 +7,23 @@ type SurveySchema struct {
 	BaseConf     BaseConf     `json:"base_conf" binding:"required" desc:"基础配置"`
 	QuestionConf QuestionConf `json:"question_conf" binding:"required" desc:"题目配置"`
 	BannerConf   BannerConf   `json:"banner_conf" binding:"required" desc:"页头配置"`
+	QuizConf     *QuizConf    `json:"quiz_conf,omitempty" desc:"测验配置 type=3时生效"`
+	I18nConf     *I18nConf    `json:"i18n_conf,omitempty" desc:"多语言配置 为空表示仅使用默认文本"`
 }
 
 type BaseConf struct {
-	BeginTime       string          `json:"begin_time" binding:"required,datetime=2006-01-02 15:04:05" desc:"问卷有效期 开始时间"`
-	EndTime         string          `json:"end_time" binding:"required,datetime=2006-01-02 15:04:05" desc:"问卷有效期 结束时间"`
-	IsLoginRequired bool            `json:"is_login_required" desc:"是否需要登录"`
-	DailyLimit      int64           `json:"daily_limit" binding:"gte=0" desc:"每日提交限制 is_login_required=true时生效"`
-	TotalLimit      int64           `json:"total_limit" binding:"omitempty,gte=0,gtefield=DailyLimit" desc:"总提交限制 is_login_required=true时生效"`
-	AllowedUserType []comm.UserType `json:"allowed_user_type" binding:"unique,dive,oneof=undergrad postgrad" desc:"允许提交的用户类型 is_login_required=true时生效"`
+	BeginTime       string               `json:"begin_time" binding:"required,datetime=2006-01-02 15:04:05" desc:"问卷有效期 开始时间"`
+	EndTime         string               `json:"end_time" binding:"required,datetime=2006-01-02 15:04:05" desc:"问卷有效期 结束时间"`
+	IsLoginRequired bool                 `json:"is_login_required" desc:"是否需要登录"`
+	DailyLimit      int64                `json:"daily_limit" binding:"gte=0" desc:"每日提交限制 is_login_required=true时生效"`
+	TotalLimit      int64                `json:"total_limit" binding:"omitempty,gte=0,gtefield=DailyLimit" desc:"总提交限制 is_login_required=true时生效"`
+	AllowedUserType []comm.UserType      `json:"allowed_user_type" binding:"unique,dive,oneof=undergrad postgrad" desc:"允许提交的用户类型 is_login_required=true时生效"`
+	UseAllowlist    bool                 `json:"use_allowlist,omitempty" desc:"是否仅允许答题名单内的用户访问及提交 is_login_required=true时生效"`
+	RetentionDays   int                  `json:"retention_days,omitempty" binding:"gte=0,lte=3650" desc:"答卷保留天数 问卷结束超过该天数后按retention_action处理 0表示永久保留"`
+	RetentionAction comm.RetentionAction `json:"retention_action,omitempty" binding:"required_with=RetentionDays,omitempty,oneof=purge anonymize" desc:"到期处理方式 purge:删除答卷 anonymize:清除用户名及敏感信息题目答案 统计数据均保留"`
+	AccessMode      comm.AccessMode      `json:"access_mode,omitempty" binding:"omitempty,oneof=password code" desc:"访问限制 空:不限制 password:统一访问密码 code:管理员生成的一次性访问码"`
+	AccessPassword  string               `json:"access_password,omitempty" binding:"required_if=AccessMode password,omitempty,max=32" desc:"访问密码 access_mode=password时生效"`
+	AsyncSubmit     bool                 `json:"async_submit,omitempty" desc:"是否异步提交 答卷写入提交队列后由submit-worker命令批量入库 答题者凭提交凭证查询入库结果 适用于投票等高峰场景"`
 }
 
 type QuestionConf struct {
@@ -25,7 +33,7 @@ type QuestionConf struct {
 type QuestionItem struct {
 	// 基本结构
 	ID    string            `json:"id" binding:"required" desc:"题目ID"`
-	Type  comm.QuestionType `json:"type" binding:"required,oneof=text textarea radio checkbox vote-radio vote-checkbox upload" desc:"题型"`
+	Type  comm.QuestionType `json:"type" binding:"required,oneof=text textarea radio checkbox vote-radio vote-checkbox upload cascade" desc:"题型"`
 	Title string            `json:"title" binding:"required" desc:"题目标题"`
 	Desc  string            `json:"desc" desc:"题目描述"`
 
@@ -38,6 +46,7 @@ type QuestionItem struct {
 	TextRange   *TextRange   `json:"text_range,omitempty" binding:"required_if=Valid *" desc:"文本长度区间限制 valid=*时生效"`
 	Regex       string       `json:"regex,omitempty" desc:"正则表达式 valid=*时生效"`
 	NumberRange *NumberRange `json:"number_range,omitempty" binding:"required_if=Valid n" desc:"数值区间限制 valid=n时生效"`
+	Sensitive   bool         `json:"sensitive,omitempty" desc:"是否为敏感信息 加密存储且答卷列表中脱敏显示 valid=m/idcard时自动生效"`
 
 	// 选项类题型
 	Options              []Option `json:"options,omitempty" binding:"required_if=Type radio,required_if=Type checkbox,required_if=Type vote-radio,required_if=Type vote-checkbox,omitempty,min=1,dive" desc:"选项列表"`
@@ -53,6 +62,23 @@ type QuestionItem struct {
 	AllowedFileType []string `json:"allowed_file_type,omitempty" binding:"unique" desc:"允许上传的文件类型 空表示不限制 upload_type=file时生效"`
 	MaxFileSize     int      `json:"max_file_size,omitempty" binding:"required_if=Type upload,omitempty,gte=1,lte=100" desc:"最大上传文件大小 单位MB"`
 	MaxFileNum      int      `json:"max_file_num,omitempty" binding:"required_if=Type upload,omitempty,gte=1,lte=10" desc:"最多上传文件数量"`
+
+	// 级联选择题型
+	CascadeOptions []CascadeNode `json:"cascade_options,omitempty" binding:"omitempty,dive" desc:"级联选项树 type=cascade时生效"`
+	DatasetID      int64         `json:"dataset_id,omitempty" binding:"gte=0" desc:"级联数据集ID 非0时保存问卷将以数据集内容填充cascade_options type=cascade时生效"`
+
+	// 测验功能
+	Score   int      `json:"score,omitempty" binding:"gte=0" desc:"题目分值 测验生效 选项类题型为0时按所选选项分值累加"`
+	Answers []string `json:"answers,omitempty" binding:"omitempty,dive,max=256" desc:"正确答案 任一匹配即判对 输入类题型 测验生效"`
+
+	// 多语言
+	I18n map[string]QuestionText `json:"i18n,omitempty" desc:"多语言文本 键为语言标识 i18n_conf.locales中的语言生效"`
+}
+
+type QuestionText struct {
+	Title       string `json:"title" desc:"题目标题"`
+	Desc        string `json:"desc,omitempty" desc:"题目描述 为空时使用默认文本"`
+	Placeholder string `json:"placeholder,omitempty" desc:"引导提示文案 为空时使用默认文本"`
 }
 
 type TextRange struct {
@@ -72,6 +98,21 @@ type Option struct {
 	OthersKey   string `json:"others_key,omitempty" binding:"required_if=Others true" desc:"自定义输入内容ID others=true时生效"`
 	MustOthers  bool   `json:"must_others,omitempty" desc:"自定义输入内容是否必填 others=true时生效"`
 	Placeholder string `json:"placeholder,omitempty" desc:"输入提示文案 others=true时生效"`
+	IsCorrect   bool   `json:"is_correct,omitempty" desc:"是否为正确选项 测验生效"`
+	Score       int    `json:"score,omitempty" binding:"gte=0" desc:"选项分值 题目分值为0时生效 测验生效"`
+
+	I18n map[string]OptionText `json:"i18n,omitempty" desc:"多语言文本 键为语言标识 i18n_conf.locales中的语言生效"`
+}
+
+type OptionText struct {
+	Text        string `json:"text" desc:"选项文本"`
+	Placeholder string `json:"placeholder,omitempty" desc:"输入提示文案 为空时使用默认文本"`
+}
+
+type CascadeNode struct {
+	ID       string        `json:"id" binding:"required,max=16" desc:"节点ID 同一题目内唯一"`
+	Text     string        `json:"text" binding:"required" desc:"节点文本"`
+	Children []CascadeNode `json:"children,omitempty" binding:"omitempty,dive" desc:"下级节点列表"`
 }
 
 type BannerConf struct {
@@ -81,4 +122,21 @@ type BannerConf struct {
 type TitleConf struct {
 	MainTitle string `json:"main_title" binding:"required" desc:"主标题"`
 	SubTitle  string `json:"sub_title" desc:"页头文案"`
+
+	I18n map[string]TitleText `json:"i18n,omitempty" desc:"多语言文本 键为语言标识 i18n_conf.locales中的语言生效"`
+}
+
+type TitleText struct {
+	MainTitle string `json:"main_title" desc:"主标题"`
+	SubTitle  string `json:"sub_title,omitempty" desc:"页头文案 为空时使用默认文本"`
+}
+
+type QuizConf struct {
+	ShowScore  bool `json:"show_score" desc:"提交后是否显示得分"`
+	ShowAnswer bool `json:"show_answer" desc:"提交后是否显示正确答案"`
+}
+
+type I18nConf struct {
+	DefaultLocale string   `json:"default_locale" binding:"required,bcp47_language_tag" desc:"默认语言 对应各项默认文本 如zh-CN"`
+	Locales       []string `json:"locales" binding:"unique,dive,bcp47_language_tag" desc:"启用的其他语言列表 如en"`
 }
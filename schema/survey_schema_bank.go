@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/samber/lo"
+)
+
+// answerKeys 问卷内已占用的答卷键 包括题目ID及自定义输入内容ID
+func (s *SurveySchema) answerKeys() map[string]bool {
+	keys := make(map[string]bool)
+	for _, item := range s.QuestionConf.Items {
+		keys[item.ID] = true
+		for _, opt := range item.Options {
+			if opt.OthersKey != "" {
+				keys[opt.OthersKey] = true
+			}
+		}
+	}
+	return keys
+}
+
+// InsertItems 为题目重新生成题目ID及自定义输入内容ID后插入到afterID对应题目之后 afterID为空时追加到末尾
+// 返回插入题目的新ID列表
+func (s *SurveySchema) InsertItems(items []QuestionItem, afterID string) ([]string, error) {
+	pos := len(s.QuestionConf.Items)
+	if afterID != "" {
+		idx := slices.IndexFunc(s.QuestionConf.Items, func(item QuestionItem) bool {
+			return item.ID == afterID
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("question(id=%s) not found", afterID)
+		}
+		pos = idx + 1
+	}
+
+	keys := s.answerKeys()
+	newKey := func() string {
+		for {
+			key := lo.RandomString(8, lo.AlphanumericCharset)
+			if !keys[key] {
+				keys[key] = true
+				return key
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(items))
+	idMap := make(map[string]string, len(items))
+	for i := range items {
+		item := &items[i]
+		idMap[item.ID] = newKey()
+		item.ID = idMap[item.ID]
+		item.Options = slices.Clone(item.Options)
+		for j := range item.Options {
+			if item.Options[j].OthersKey != "" {
+				item.Options[j].OthersKey = newKey()
+			}
+		}
+		ids = append(ids, item.ID)
+	}
+
+	// 插入题目之间的答案引用同步替换为新ID
+	for i := range items {
+		items[i].remapPipeRefs(idMap)
+	}
+	s.QuestionConf.Items = slices.Insert(s.QuestionConf.Items, pos, items...)
+	return ids, nil
+}
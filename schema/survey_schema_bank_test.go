@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"testing"
+
+	"app/comm"
+)
+
+func TestInsertItemsRemapPipeRefs(t *testing.T) {
+	s := &SurveySchema{QuestionConf: QuestionConf{Items: []QuestionItem{
+		{ID: "a", Type: comm.QuestionTypeText, Title: "姓名", Valid: "*"},
+	}}}
+	items := []QuestionItem{
+		{ID: "a", Type: comm.QuestionTypeText, Title: "昵称", Valid: "*"},
+		{
+			ID:    "b",
+			Type:  comm.QuestionTypeRadio,
+			Title: "{{ a }}喜欢的颜色",
+			Desc:  "引用问卷题目{{x}}",
+			I18n:  map[string]QuestionText{"en": {Title: "{{a}}'s color"}},
+			Options: []Option{
+				{ID: "o1", Text: "与{{a}}相同"},
+			},
+		},
+	}
+
+	ids, err := s.InsertItems(items, "a")
+	if err != nil {
+		t.Fatalf("InsertItems() error = %v", err)
+	}
+	if len(ids) != 2 || ids[0] == "a" || ids[1] == "b" {
+		t.Fatalf("InsertItems() ids = %q, want fresh ids", ids)
+	}
+
+	inserted := s.QuestionConf.Items[2]
+	if want := "{{" + ids[0] + "}}喜欢的颜色"; inserted.Title != want {
+		t.Errorf("Title = %q, want %q", inserted.Title, want)
+	}
+	if want := "引用问卷题目{{x}}"; inserted.Desc != want {
+		t.Errorf("Desc = %q, want %q", inserted.Desc, want)
+	}
+	if want := "{{" + ids[0] + "}}'s color"; inserted.I18n["en"].Title != want {
+		t.Errorf("I18n title = %q, want %q", inserted.I18n["en"].Title, want)
+	}
+	if want := "与{{" + ids[0] + "}}相同"; inserted.Options[0].Text != want {
+		t.Errorf("Option text = %q, want %q", inserted.Options[0].Text, want)
+	}
+}
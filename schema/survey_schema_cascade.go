@@ -0,0 +1,80 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// CascadeNodeInfo 级联节点展开信息
+type CascadeNodeInfo struct {
+	ID       string
+	Text     string
+	ParentID string
+	Level    int
+}
+
+// VerifyCascadeNodes 校验级联选项树 节点ID在整棵树内唯一
+func VerifyCascadeNodes(nodes []CascadeNode) error {
+	ids := make(map[string]bool)
+	for _, node := range FlattenCascade(nodes) {
+		if ids[node.ID] {
+			return fmt.Errorf("duplicate cascade node id: %s", node.ID)
+		}
+		ids[node.ID] = true
+	}
+	return nil
+}
+
+// FlattenCascade 按先序遍历展开级联选项树 Level 从1开始
+func FlattenCascade(nodes []CascadeNode) []CascadeNodeInfo {
+	list := make([]CascadeNodeInfo, 0)
+	var walk func(nodes []CascadeNode, parentID string, level int)
+	walk = func(nodes []CascadeNode, parentID string, level int) {
+		for _, node := range nodes {
+			list = append(list, CascadeNodeInfo{
+				ID:       node.ID,
+				Text:     node.Text,
+				ParentID: parentID,
+				Level:    level,
+			})
+			walk(node.Children, node.ID, level+1)
+		}
+	}
+	walk(nodes, "", 1)
+	return list
+}
+
+// CascadePath 解析级联题答案 答案为自顶向下以逗号分隔的节点ID路径 须选择至叶子节点
+func (item *QuestionItem) CascadePath(answer string) ([]CascadeNode, bool) {
+	ids := strings.Split(answer, ",")
+	path := make([]CascadeNode, 0, len(ids))
+	nodes := item.CascadeOptions
+	for _, id := range ids {
+		node, ok := lo.Find(nodes, func(n CascadeNode) bool {
+			return n.ID == id
+		})
+		if !ok {
+			return nil, false
+		}
+		path = append(path, node)
+		nodes = node.Children
+	}
+	return path, len(path) > 0 && len(nodes) == 0
+}
+
+// StatsOptionIDs 题目需要统计的选项ID列表 级联题为全部节点ID
+func (item *QuestionItem) StatsOptionIDs() []string {
+	if item.IsCascadeType() {
+		return lo.Map(FlattenCascade(item.CascadeOptions), func(node CascadeNodeInfo, _ int) string {
+			return node.ID
+		})
+	}
+	if item.IsOptionType() {
+		return lo.Map(item.Options, func(opt Option, _ int) string {
+			return opt.ID
+		})
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package schema
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bytedance/sonic"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	regexMobile = regexp.MustCompile(`^1[3-9]\d{9}$`)
+	regexEmail  = regexp.MustCompile(`^\w+([-+.]\w+)*@\w+([-.]\w+)*\.\w+([-.]\w+)*$`)
+	regexIDCard = regexp.MustCompile(`(^\d{15}$)|(^\d{18}$)|(^\d{17}(\d|X|x)$)`)
+
+	imageFileTypes = []string{"jpg", "jpeg", "png", "webp"}
+)
+
+// CompiledSchema 预编译的问卷结构 提交答卷时使用 避免每次请求重复反序列化及解析
+// 构建后只读 可被多个请求共享 调用方不可修改Schema
+type CompiledSchema struct {
+	Schema       SurveySchema
+	BeginTime    time.Time // 问卷有效期 开始时间
+	EndTime      time.Time // 问卷有效期 结束时间
+	SensitiveIDs []string  // 敏感信息题目ID列表
+
+	items []compiledItem
+}
+
+// compiledItem 预编译的题目 校验所需的查找表及解析结果
+type compiledItem struct {
+	*QuestionItem
+	options   map[string]*Option  // 选项ID -> 选项
+	numberMin decimal.Decimal     // 数值下限 hasNumber=true时生效
+	numberMax decimal.Decimal     // 数值上限 hasNumber=true时生效
+	hasNumber bool                // 是否限制数值区间
+	regex     *regexp.Regexp      // 内容格式正则 为nil表示不限制
+	regexErr  bool                // 自定义正则表达式是否无效 无效时拒绝所有非空答案
+	fileTypes map[string]struct{} // 允许上传的文件类型 为nil表示不限制
+}
+
+// Compile 反序列化并预编译问卷结构
+func Compile(schemaStr string) (*CompiledSchema, error) {
+	c := &CompiledSchema{}
+	if err := sonic.UnmarshalString(schemaStr, &c.Schema); err != nil {
+		return nil, err
+	}
+
+	c.BeginTime, _ = time.ParseInLocation(time.DateTime, c.Schema.BaseConf.BeginTime, time.Local)
+	c.EndTime, _ = time.ParseInLocation(time.DateTime, c.Schema.BaseConf.EndTime, time.Local)
+	c.SensitiveIDs = c.Schema.SensitiveIDs()
+
+	c.items = make([]compiledItem, len(c.Schema.QuestionConf.Items))
+	for i := range c.Schema.QuestionConf.Items {
+		c.items[i] = compileItem(&c.Schema.QuestionConf.Items[i])
+	}
+	return c, nil
+}
+
+func compileItem(item *QuestionItem) compiledItem {
+	ci := compiledItem{QuestionItem: item}
+	switch {
+	case item.IsOptionType():
+		ci.options = make(map[string]*Option, len(item.Options))
+		for i := range item.Options {
+			ci.options[item.Options[i].ID] = &item.Options[i]
+		}
+	case item.IsInputType():
+		switch item.Valid {
+		case "n":
+			if item.NumberRange != nil {
+				ci.numberMin, _ = decimal.NewFromString(item.NumberRange.Min)
+				ci.numberMax, _ = decimal.NewFromString(item.NumberRange.Max)
+				ci.hasNumber = true
+			}
+		case "m":
+			ci.regex = regexMobile
+		case "e":
+			ci.regex = regexEmail
+		case "idcard":
+			ci.regex = regexIDCard
+		default:
+			if item.Regex != "" {
+				regex, err := regexp.Compile(item.Regex)
+				ci.regex, ci.regexErr = regex, err != nil
+			}
+		}
+	case item.IsUploadType():
+		fileTypes := item.AllowedFileType
+		if item.UploadType == "image" {
+			fileTypes = imageFileTypes
+		}
+		if len(fileTypes) > 0 {
+			ci.fileTypes = make(map[string]struct{}, len(fileTypes))
+			for _, ext := range fileTypes {
+				ci.fileTypes[ext] = struct{}{}
+			}
+		}
+	}
+	return ci
+}
+
+// Validate 校验答卷结果 返回答案计入的统计项
+func (c *CompiledSchema) Validate(answerMap map[string]string) ([]StatsRef, error) {
+	var refs []StatsRef
+	for i := range c.items {
+		item := &c.items[i]
+		val, exists := answerMap[item.ID]
+
+		// 检查必填
+		if !exists || val == "" {
+			if item.IsRequired {
+				return nil, fmt.Errorf("question(id=%s) is required", item.ID)
+			}
+			continue
+		}
+
+		switch {
+		case item.IsOptionType():
+			selectedOpts := strings.Split(val, ",")
+
+			// 多选题校验选项数量
+			if item.IsCheckboxType() {
+				if (item.MinNum > 0 && len(selectedOpts) < item.MinNum) ||
+					(item.MaxNum > 0 && len(selectedOpts) > item.MaxNum) {
+					return nil, fmt.Errorf("question(id=%s) option count out of range", item.ID)
+				}
+			}
+
+			// 校验选项是否存在
+			for _, optID := range selectedOpts {
+				opt, ok := item.options[optID]
+				if !ok {
+					return nil, fmt.Errorf("question(id=%s) option(id=%s) not found", item.ID, optID)
+				}
+				refs = append(refs, StatsRef{QuestionID: item.ID, OptionID: optID})
+
+				// 检查自定义输入内容选项
+				if opt.Others && opt.MustOthers && answerMap[opt.OthersKey] == "" {
+					return nil, fmt.Errorf("question(id=%s) option(id=%s) others is required", item.ID, optID)
+				}
+			}
+		case item.IsCascadeType():
+			// 级联题校验节点路径 并收集路径上各级节点的统计项
+			path, ok := item.CascadePath(val)
+			if !ok {
+				return nil, fmt.Errorf("question(id=%s) cascade path %q not found", item.ID, val)
+			}
+			for _, node := range path {
+				refs = append(refs, StatsRef{QuestionID: item.ID, OptionID: node.ID})
+			}
+		case item.IsInputType():
+			if err := item.validateInput(val); err != nil {
+				return nil, err
+			}
+		case item.IsUploadType():
+			files := strings.Split(val, ",")
+			if item.MaxFileNum > 0 && len(files) > item.MaxFileNum {
+				return nil, fmt.Errorf("question(id=%s) file count out of range", item.ID)
+			}
+			if item.fileTypes == nil {
+				continue
+			}
+			for _, f := range files {
+				ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(f), "."))
+				if _, ok := item.fileTypes[ext]; !ok {
+					return nil, fmt.Errorf("question(id=%s) file type %q not allowed", item.ID, ext)
+				}
+			}
+		}
+	}
+	return refs, nil
+}
+
+// validateInput 校验输入类题目答案
+func (item *compiledItem) validateInput(val string) error {
+	if item.Valid == "n" {
+		valDec, err := decimal.NewFromString(val)
+		if err != nil {
+			return fmt.Errorf("question(id=%s) invalid number %q", item.ID, val)
+		}
+		if item.hasNumber && (valDec.LessThan(item.numberMin) || valDec.GreaterThan(item.numberMax)) {
+			return fmt.Errorf("question(id=%s) number %q out of range", item.ID, val)
+		}
+		return nil
+	}
+
+	if item.TextRange != nil {
+		l := utf8.RuneCountInString(val)
+		if l < item.TextRange.Min || l > item.TextRange.Max {
+			return fmt.Errorf("question(id=%s) text length out of range", item.ID)
+		}
+	}
+	if item.regexErr || (item.regex != nil && !item.regex.MatchString(val)) {
+		return fmt.Errorf("question(id=%s) answer %q format invalid", item.ID, val)
+	}
+	return nil
+}
@@ -0,0 +1,402 @@
+package schema
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	"app/comm"
+)
+
+func compileSchema(t testing.TB, s *SurveySchema) *CompiledSchema {
+	t.Helper()
+	str, err := sonic.MarshalString(s)
+	if err != nil {
+		t.Fatalf("MarshalString() error = %v", err)
+	}
+	c, err := Compile(str)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	return c
+}
+
+func validateSchema(items ...QuestionItem) *SurveySchema {
+	return &SurveySchema{
+		Version: "1.0.0",
+		BaseConf: BaseConf{
+			BeginTime: "2026-01-01 00:00:00",
+			EndTime:   "2026-12-31 23:59:59",
+		},
+		QuestionConf: QuestionConf{Items: items},
+	}
+}
+
+func TestCompiledSchemaValidate(t *testing.T) {
+	cascade := []CascadeNode{
+		{ID: "zj", Text: "浙江", Children: []CascadeNode{
+			{ID: "hz", Text: "杭州"},
+			{ID: "nb", Text: "宁波"},
+		}},
+		{ID: "sh", Text: "上海"},
+	}
+
+	tests := []struct {
+		name    string
+		item    QuestionItem
+		answers map[string]string
+		refs    []StatsRef
+		wantErr bool
+	}{
+		{
+			name:    "必填未填",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeText, Valid: "*", IsRequired: true},
+			answers: map[string]string{"q": ""},
+			wantErr: true,
+		},
+		{
+			name:    "非必填未填",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeText, Valid: "*"},
+			answers: map[string]string{},
+		},
+		{
+			name:    "多选题少于最少选择数",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeCheckbox, MinNum: 2, MaxNum: 3, Options: []Option{{ID: "a"}, {ID: "b"}, {ID: "c"}}},
+			answers: map[string]string{"q": "a"},
+			wantErr: true,
+		},
+		{
+			name:    "多选题超出最多选择数",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeCheckbox, MaxNum: 2, Options: []Option{{ID: "a"}, {ID: "b"}, {ID: "c"}}},
+			answers: map[string]string{"q": "a,b,c"},
+			wantErr: true,
+		},
+		{
+			name:    "多选题选项数量合法",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeCheckbox, MinNum: 1, MaxNum: 2, Options: []Option{{ID: "a"}, {ID: "b"}, {ID: "c"}}},
+			answers: map[string]string{"q": "a,c"},
+			refs:    []StatsRef{{QuestionID: "q", OptionID: "a"}, {QuestionID: "q", OptionID: "c"}},
+		},
+		{
+			name:    "选项不存在",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeRadio, Options: []Option{{ID: "a"}}},
+			answers: map[string]string{"q": "x"},
+			wantErr: true,
+		},
+		{
+			name:    "自定义输入内容必填未填",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeRadio, Options: []Option{{ID: "a", Others: true, OthersKey: "qo", MustOthers: true}}},
+			answers: map[string]string{"q": "a"},
+			wantErr: true,
+		},
+		{
+			name:    "自定义输入内容必填已填",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeRadio, Options: []Option{{ID: "a", Others: true, OthersKey: "qo", MustOthers: true}}},
+			answers: map[string]string{"q": "a", "qo": "其他"},
+			refs:    []StatsRef{{QuestionID: "q", OptionID: "a"}},
+		},
+		{
+			name:    "级联题完整路径",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeCascade, CascadeOptions: cascade},
+			answers: map[string]string{"q": "zj,hz"},
+			refs:    []StatsRef{{QuestionID: "q", OptionID: "zj"}, {QuestionID: "q", OptionID: "hz"}},
+		},
+		{
+			name:    "级联题未选至叶子节点",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeCascade, CascadeOptions: cascade},
+			answers: map[string]string{"q": "zj"},
+			wantErr: true,
+		},
+		{
+			name:    "级联题路径不存在",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeCascade, CascadeOptions: cascade},
+			answers: map[string]string{"q": "sh,hz"},
+			wantErr: true,
+		},
+		{
+			name:    "数值在区间内",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeText, Valid: "n", NumberRange: &NumberRange{Min: "1", Max: "10.5"}},
+			answers: map[string]string{"q": "10.5"},
+		},
+		{
+			name:    "数值超出区间",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeText, Valid: "n", NumberRange: &NumberRange{Min: "1", Max: "10.5"}},
+			answers: map[string]string{"q": "10.6"},
+			wantErr: true,
+		},
+		{
+			name:    "数值格式错误",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeText, Valid: "n", NumberRange: &NumberRange{Min: "1", Max: "10"}},
+			answers: map[string]string{"q": "abc"},
+			wantErr: true,
+		},
+		{
+			name:    "手机号格式",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeText, Valid: "m"},
+			answers: map[string]string{"q": "12345678901"},
+			wantErr: true,
+		},
+		{
+			name:    "自定义正则匹配",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeText, Valid: "*", TextRange: &TextRange{Min: 1, Max: 10}, Regex: `^[a-z]+$`},
+			answers: map[string]string{"q": "abc"},
+		},
+		{
+			name:    "自定义正则不匹配",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeText, Valid: "*", TextRange: &TextRange{Min: 1, Max: 10}, Regex: `^[a-z]+$`},
+			answers: map[string]string{"q": "ABC"},
+			wantErr: true,
+		},
+		{
+			name:    "自定义正则无效时拒绝非空答案",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeText, Valid: "*", TextRange: &TextRange{Min: 1, Max: 10}, Regex: `([a-z`},
+			answers: map[string]string{"q": "abc"},
+			wantErr: true,
+		},
+		{
+			name:    "文本长度按字符计算",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeText, Valid: "*", TextRange: &TextRange{Min: 1, Max: 2}},
+			answers: map[string]string{"q": "你好"},
+		},
+		{
+			name:    "图片格式合法",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeUpload, UploadType: "image", MaxFileNum: 2},
+			answers: map[string]string{"q": "a.JPG,b.webp"},
+		},
+		{
+			name:    "图片格式不合法",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeUpload, UploadType: "image", MaxFileNum: 2},
+			answers: map[string]string{"q": "a.gif"},
+			wantErr: true,
+		},
+		{
+			name:    "文件类型不在允许列表",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeUpload, UploadType: "file", AllowedFileType: []string{"pdf"}, MaxFileNum: 1},
+			answers: map[string]string{"q": "a.docx"},
+			wantErr: true,
+		},
+		{
+			name:    "文件类型不限制",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeUpload, UploadType: "file", MaxFileNum: 1},
+			answers: map[string]string{"q": "a.docx"},
+		},
+		{
+			name:    "上传文件数量超出限制",
+			item:    QuestionItem{ID: "q", Type: comm.QuestionTypeUpload, UploadType: "file", MaxFileNum: 1},
+			answers: map[string]string{"q": "a.pdf,b.pdf"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := compileSchema(t, validateSchema(tt.item))
+			refs, err := c.Validate(tt.answers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !slices.Equal(refs, tt.refs) {
+				t.Errorf("Validate() refs = %v, want %v", refs, tt.refs)
+			}
+		})
+	}
+}
+
+// benchSchema 基准测试用问卷 包含各类题型共20题
+func benchSchema() (*SurveySchema, map[string]string) {
+	items := make([]QuestionItem, 0, 20)
+	answers := make(map[string]string, 20)
+	for i := range 4 {
+		id := fmt.Sprintf("r%d", i)
+		items = append(items, QuestionItem{ID: id, Type: comm.QuestionTypeRadio, Title: id, IsRequired: true, Options: []Option{
+			{ID: "a", Text: "A"}, {ID: "b", Text: "B"}, {ID: "c", Text: "C", Others: true, OthersKey: id + "o"},
+		}})
+		answers[id] = "b"
+
+		id = fmt.Sprintf("c%d", i)
+		items = append(items, QuestionItem{ID: id, Type: comm.QuestionTypeCheckbox, Title: id, MinNum: 1, MaxNum: 3, Options: []Option{
+			{ID: "a", Text: "A"}, {ID: "b", Text: "B"}, {ID: "c", Text: "C"}, {ID: "d", Text: "D"},
+		}})
+		answers[id] = "a,c,d"
+
+		id = fmt.Sprintf("t%d", i)
+		items = append(items, QuestionItem{ID: id, Type: comm.QuestionTypeText, Title: id, Valid: "*", TextRange: &TextRange{Min: 1, Max: 32}, Regex: `^[A-Za-z0-9_]+$`})
+		answers[id] = "answer_01"
+
+		id = fmt.Sprintf("n%d", i)
+		items = append(items, QuestionItem{ID: id, Type: comm.QuestionTypeText, Title: id, Valid: "n", NumberRange: &NumberRange{Min: "0", Max: "100"}})
+		answers[id] = "42.5"
+	}
+	items = append(items,
+		QuestionItem{ID: "m", Type: comm.QuestionTypeText, Title: "m", Valid: "m"},
+		QuestionItem{ID: "e", Type: comm.QuestionTypeText, Title: "e", Valid: "e"},
+		QuestionItem{ID: "u", Type: comm.QuestionTypeUpload, Title: "u", UploadType: "image", MaxFileNum: 3, MaxFileSize: 5},
+		QuestionItem{ID: "k", Type: comm.QuestionTypeCascade, Title: "k", CascadeOptions: []CascadeNode{
+			{ID: "zj", Text: "浙江", Children: []CascadeNode{{ID: "hz", Text: "杭州"}, {ID: "nb", Text: "宁波"}}},
+		}},
+	)
+	answers["m"] = "13800138000"
+	answers["e"] = "someone@example.com"
+	answers["u"] = "a.png,b.jpg"
+	answers["k"] = "zj,nb"
+	return validateSchema(items...), answers
+}
+
+// legacyValidate 预编译前的校验方式 每次请求反序列化问卷结构并现场解析数值区间及正则
+func legacyValidate(schemaStr string, answerMap map[string]string) ([]StatsRef, error) {
+	var s SurveySchema
+	if err := sonic.UnmarshalString(schemaStr, &s); err != nil {
+		return nil, err
+	}
+
+	var refs []StatsRef
+	for _, item := range s.QuestionConf.Items {
+		val, exists := answerMap[item.ID]
+		if item.IsRequired && (!exists || val == "") {
+			return nil, fmt.Errorf("question(id=%s) is required", item.ID)
+		}
+		if !exists || val == "" {
+			continue
+		}
+
+		switch {
+		case item.IsOptionType():
+			selectedOpts := strings.Split(val, ",")
+			if item.IsCheckboxType() {
+				if (item.MinNum > 0 && len(selectedOpts) < item.MinNum) ||
+					(item.MaxNum > 0 && len(selectedOpts) > item.MaxNum) {
+					return nil, fmt.Errorf("question(id=%s) option count out of range", item.ID)
+				}
+			}
+			optMap := lo.KeyBy(item.Options, func(o Option) string {
+				return o.ID
+			})
+			for _, optID := range selectedOpts {
+				opt, ok := optMap[optID]
+				if !ok {
+					return nil, fmt.Errorf("question(id=%s) option(id=%s) not found", item.ID, optID)
+				}
+				refs = append(refs, StatsRef{QuestionID: item.ID, OptionID: optID})
+				if opt.Others && opt.MustOthers && answerMap[opt.OthersKey] == "" {
+					return nil, fmt.Errorf("question(id=%s) option(id=%s) others is required", item.ID, optID)
+				}
+			}
+		case item.IsCascadeType():
+			path, ok := item.CascadePath(val)
+			if !ok {
+				return nil, fmt.Errorf("question(id=%s) cascade path %q not found", item.ID, val)
+			}
+			for _, node := range path {
+				refs = append(refs, StatsRef{QuestionID: item.ID, OptionID: node.ID})
+			}
+		case item.IsInputType():
+			switch item.Valid {
+			case "n":
+				valDec, err := decimal.NewFromString(val)
+				if err != nil {
+					return nil, err
+				}
+				if item.NumberRange != nil {
+					minDec, _ := decimal.NewFromString(item.NumberRange.Min)
+					maxDec, _ := decimal.NewFromString(item.NumberRange.Max)
+					if valDec.LessThan(minDec) || valDec.GreaterThan(maxDec) {
+						return nil, fmt.Errorf("question(id=%s) number out of range", item.ID)
+					}
+				}
+			case "m":
+				if !regexMobile.MatchString(val) {
+					return nil, fmt.Errorf("question(id=%s) format invalid", item.ID)
+				}
+			case "e":
+				if !regexEmail.MatchString(val) {
+					return nil, fmt.Errorf("question(id=%s) format invalid", item.ID)
+				}
+			case "idcard":
+				if !regexIDCard.MatchString(val) {
+					return nil, fmt.Errorf("question(id=%s) format invalid", item.ID)
+				}
+			default:
+				if item.TextRange != nil {
+					l := len([]rune(val))
+					if l < item.TextRange.Min || l > item.TextRange.Max {
+						return nil, fmt.Errorf("question(id=%s) text length out of range", item.ID)
+					}
+				}
+				if item.Regex != "" {
+					if match, _ := regexp.MatchString(item.Regex, val); !match {
+						return nil, fmt.Errorf("question(id=%s) format invalid", item.ID)
+					}
+				}
+			}
+		case item.IsUploadType():
+			files := strings.Split(val, ",")
+			if item.MaxFileNum > 0 && len(files) > item.MaxFileNum {
+				return nil, fmt.Errorf("question(id=%s) file count out of range", item.ID)
+			}
+			for _, f := range files {
+				ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(f), "."))
+				switch item.UploadType {
+				case "image":
+					if !slices.Contains(imageFileTypes, ext) {
+						return nil, fmt.Errorf("question(id=%s) file type %q not allowed", item.ID, ext)
+					}
+				case "file":
+					if len(item.AllowedFileType) > 0 && !slices.Contains(item.AllowedFileType, ext) {
+						return nil, fmt.Errorf("question(id=%s) file type %q not allowed", item.ID, ext)
+					}
+				}
+			}
+		}
+	}
+	return refs, nil
+}
+
+func TestLegacyValidateMatchesCompiled(t *testing.T) {
+	s, answers := benchSchema()
+	str, _ := sonic.MarshalString(s)
+	want, err := legacyValidate(str, answers)
+	if err != nil {
+		t.Fatalf("legacyValidate() error = %v", err)
+	}
+	got, err := compileSchema(t, s).Validate(answers)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Validate() refs = %v, want %v", got, want)
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	s, answers := benchSchema()
+	str, err := sonic.MarshalString(s)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("legacy", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := legacyValidate(str, answers); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("compiled", func(b *testing.B) {
+		c := compileSchema(b, s)
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := c.Validate(answers); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package schema
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/bytedance/sonic"
+	"github.com/samber/lo"
+)
+
+// SchemaDiff 问卷结构变更摘要
+type SchemaDiff struct {
+	Changed   []string `json:"changed,omitempty"`   // 变更的配置项 如base_conf.end_time
+	Added     []string `json:"added,omitempty"`     // 新增题目ID
+	Removed   []string `json:"removed,omitempty"`   // 删除题目ID
+	Modified  []string `json:"modified,omitempty"`  // 修改题目ID
+	Reordered bool     `json:"reordered,omitempty"` // 保留题目的顺序是否变更
+}
+
+// Diff 对比问卷结构 生成由before到after的变更摘要
+func Diff(before, after *SurveySchema) SchemaDiff {
+	var diff SchemaDiff
+
+	// 配置项变更
+	if before.Version != after.Version {
+		diff.Changed = append(diff.Changed, "version")
+	}
+	diff.Changed = append(diff.Changed, diffConf("base_conf", before.BaseConf, after.BaseConf)...)
+	diff.Changed = append(diff.Changed, diffConf("banner_conf.title_conf", before.BannerConf.TitleConf, after.BannerConf.TitleConf)...)
+	diff.Changed = append(diff.Changed, diffConf("quiz_conf", before.QuizConf, after.QuizConf)...)
+	diff.Changed = append(diff.Changed, diffConf("i18n_conf", before.I18nConf, after.I18nConf)...)
+
+	// 题目变更
+	beforeItems := lo.KeyBy(before.QuestionConf.Items, func(item QuestionItem) string {
+		return item.ID
+	})
+	afterItems := lo.KeyBy(after.QuestionConf.Items, func(item QuestionItem) string {
+		return item.ID
+	})
+	var beforeKept, afterKept []string
+	for _, item := range before.QuestionConf.Items {
+		if _, ok := afterItems[item.ID]; !ok {
+			diff.Removed = append(diff.Removed, item.ID)
+			continue
+		}
+		beforeKept = append(beforeKept, item.ID)
+	}
+	for _, item := range after.QuestionConf.Items {
+		old, ok := beforeItems[item.ID]
+		if !ok {
+			diff.Added = append(diff.Added, item.ID)
+			continue
+		}
+		afterKept = append(afterKept, item.ID)
+		if !reflect.DeepEqual(confMap(old), confMap(item)) {
+			diff.Modified = append(diff.Modified, item.ID)
+		}
+	}
+	diff.Reordered = !slices.Equal(beforeKept, afterKept)
+
+	return diff
+}
+
+// diffConf 按JSON字段对比配置 返回变更的字段路径
+func diffConf(prefix string, before, after any) []string {
+	beforeMap, afterMap := confMap(before), confMap(after)
+	keys := lo.Uniq(append(lo.Keys(beforeMap), lo.Keys(afterMap)...))
+	slices.Sort(keys)
+
+	return lo.FilterMap(keys, func(key string, _ int) (string, bool) {
+		return prefix + "." + key, !reflect.DeepEqual(beforeMap[key], afterMap[key])
+	})
+}
+
+// confMap 将配置转换为JSON字段映射 配置为空时返回nil 以JSON形式对比可忽略空切片与nil等差异
+func confMap(conf any) map[string]any {
+	str, err := sonic.MarshalString(conf)
+	if err != nil {
+		return nil
+	}
+	var m map[string]any
+	_ = sonic.UnmarshalString(str, &m)
+	return m
+}
@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/samber/lo"
+	"golang.org/x/text/language"
+)
+
+// verifyAndFixI18n 校验多语言配置 每个启用的语言须提供全部必填文本 未启用语言的文本将被清理
+func (s *SurveySchema) verifyAndFixI18n() error {
+	var locales []string
+	if s.I18nConf != nil {
+		if lo.Contains(s.I18nConf.Locales, s.I18nConf.DefaultLocale) {
+			return fmt.Errorf("locales cannot contain default_locale %s", s.I18nConf.DefaultLocale)
+		}
+		locales = s.I18nConf.Locales
+	}
+	disabled := func(locale string) bool {
+		return !lo.Contains(locales, locale)
+	}
+
+	title := &s.BannerConf.TitleConf
+	maps.DeleteFunc(title.I18n, func(locale string, _ TitleText) bool {
+		return disabled(locale)
+	})
+	for _, locale := range locales {
+		if title.I18n[locale].MainTitle == "" {
+			return fmt.Errorf("banner_conf.title_conf.i18n[%s].main_title is required", locale)
+		}
+	}
+
+	for i := range s.QuestionConf.Items {
+		item := &s.QuestionConf.Items[i]
+		maps.DeleteFunc(item.I18n, func(locale string, _ QuestionText) bool {
+			return disabled(locale)
+		})
+		for _, locale := range locales {
+			text, ok := item.I18n[locale]
+			if !ok || text.Title == "" {
+				return fmt.Errorf("question(id=%s) error: i18n[%s].title is required", item.ID, locale)
+			}
+			if !item.IsInputType() {
+				text.Placeholder = ""
+				item.I18n[locale] = text
+			}
+		}
+
+		for j := range item.Options {
+			opt := &item.Options[j]
+			maps.DeleteFunc(opt.I18n, func(locale string, _ OptionText) bool {
+				return disabled(locale)
+			})
+			for _, locale := range locales {
+				text, ok := opt.I18n[locale]
+				if !ok || text.Text == "" {
+					return fmt.Errorf("question(id=%s) option(id=%s) error: i18n[%s].text is required", item.ID, opt.ID, locale)
+				}
+				if !opt.Others {
+					text.Placeholder = ""
+					opt.I18n[locale] = text
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// MatchLocale 按查询参数及Accept-Language匹配问卷启用的语言 查询参数优先 均未匹配时返回默认语言
+// 未配置多语言时返回空
+func (s *SurveySchema) MatchLocale(lang, acceptLanguage string) string {
+	if s.I18nConf == nil {
+		return ""
+	}
+	supported := append([]string{s.I18nConf.DefaultLocale}, s.I18nConf.Locales...)
+	matcher := language.NewMatcher(lo.Map(supported, func(locale string, _ int) language.Tag {
+		return language.Make(locale)
+	}))
+
+	if lang != "" {
+		if tag, err := language.Parse(lang); err == nil {
+			if _, idx, conf := matcher.Match(tag); conf != language.No {
+				return supported[idx]
+			}
+		}
+	}
+	if acceptLanguage != "" {
+		if tags, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil && len(tags) > 0 {
+			if _, idx, conf := matcher.Match(tags...); conf != language.No {
+				return supported[idx]
+			}
+		}
+	}
+	return s.I18nConf.DefaultLocale
+}
+
+// Localize 以指定语言的文本替换默认文本并清理全部多语言文本 语言为默认语言或未启用时仅清理
+func (s *SurveySchema) Localize(locale string) {
+	active := s.I18nConf != nil && lo.Contains(s.I18nConf.Locales, locale)
+	override := func(dst *string, src string) {
+		if active && src != "" {
+			*dst = src
+		}
+	}
+
+	title := &s.BannerConf.TitleConf
+	override(&title.MainTitle, title.I18n[locale].MainTitle)
+	override(&title.SubTitle, title.I18n[locale].SubTitle)
+	title.I18n = nil
+
+	for i := range s.QuestionConf.Items {
+		item := &s.QuestionConf.Items[i]
+		override(&item.Title, item.I18n[locale].Title)
+		override(&item.Desc, item.I18n[locale].Desc)
+		override(&item.Placeholder, item.I18n[locale].Placeholder)
+		item.I18n = nil
+
+		for j := range item.Options {
+			opt := &item.Options[j]
+			override(&opt.Text, opt.I18n[locale].Text)
+			override(&opt.Placeholder, opt.I18n[locale].Placeholder)
+			opt.I18n = nil
+		}
+	}
+}
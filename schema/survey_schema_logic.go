@@ -23,6 +23,21 @@ func (s *SurveySchema) NormalizeAndVerify() error {
 		return fmt.Errorf("question_conf error: %w", err)
 	}
 
+	// I18nConf
+	if err := s.verifyAndFixI18n(); err != nil {
+		return fmt.Errorf("i18n_conf error: %w", err)
+	}
+
+	// 答案引用
+	if err := s.verifyPipe(); err != nil {
+		return fmt.Errorf("question_conf error: %w", err)
+	}
+
+	// QuizConf
+	if err := s.verifyAndFixQuiz(); err != nil {
+		return fmt.Errorf("quiz_conf error: %w", err)
+	}
+
 	// showStatsAfterSubmit 要求 is_login_required 为 true
 	showStatsAfterSubmit := lo.ContainsBy(s.QuestionConf.Items, func(item QuestionItem) bool {
 		return item.ShowStatsAfterSubmit
@@ -34,6 +49,30 @@ func (s *SurveySchema) NormalizeAndVerify() error {
 	return nil
 }
 
+// AdaptTo 按问卷类型调整配置 仅测验类问卷保留测验配置
+func (s *SurveySchema) AdaptTo(surveyType comm.SurveyType) {
+	if surveyType != comm.SurveyTypeQuiz {
+		s.QuizConf = nil
+		return
+	}
+	if s.QuizConf == nil {
+		s.QuizConf = &QuizConf{}
+	}
+}
+
+// Desensitize 清理不可对答题者公开的配置 (如测验正确答案、访问密码)
+func (s *SurveySchema) Desensitize() {
+	s.BaseConf.AccessPassword = ""
+	for i := range s.QuestionConf.Items {
+		item := &s.QuestionConf.Items[i]
+		item.Answers = nil
+		for j := range item.Options {
+			item.Options[j].IsCorrect = false
+			item.Options[j].Score = 0
+		}
+	}
+}
+
 func (b *BaseConf) verifyAndFix() error {
 	beginTime, _ := time.Parse(time.DateTime, b.BeginTime)
 	endTime, _ := time.Parse(time.DateTime, b.EndTime)
@@ -45,11 +84,32 @@ func (b *BaseConf) verifyAndFix() error {
 		b.DailyLimit = 0
 		b.TotalLimit = 0
 		b.AllowedUserType = nil
+		b.UseAllowlist = false
+	}
+
+	if b.RetentionDays == 0 {
+		b.RetentionAction = ""
+	}
+
+	if b.AccessMode != comm.AccessModePassword {
+		b.AccessPassword = ""
 	}
 
 	return nil
 }
 
+// ArchiveDue 问卷结束是否已超过指定天数 用于答卷归档
+func (b *BaseConf) ArchiveDue(now time.Time, days int) bool {
+	if days <= 0 {
+		return false
+	}
+	endTime, err := time.ParseInLocation(time.DateTime, b.EndTime, time.Local)
+	if err != nil {
+		return false
+	}
+	return now.After(endTime.AddDate(0, 0, days))
+}
+
 func (q *QuestionConf) verifyAndFix() error {
 	ids := make(map[string]bool)
 	for i := range q.Items {
@@ -74,6 +134,7 @@ func (item *QuestionItem) verifyAndFix() error {
 		item.TextRange = nil
 		item.Regex = ""
 		item.NumberRange = nil
+		item.Sensitive = false
 	} else {
 		if item.Valid != "*" {
 			item.TextRange = nil
@@ -155,6 +216,18 @@ func (item *QuestionItem) verifyAndFix() error {
 		}
 	}
 
+	if !item.IsCascadeType() {
+		item.CascadeOptions = nil
+		item.DatasetID = 0
+	} else {
+		if len(item.CascadeOptions) == 0 {
+			return fmt.Errorf("cascade_options cannot be empty")
+		}
+		if err := VerifyCascadeNodes(item.CascadeOptions); err != nil {
+			return err
+		}
+	}
+
 	if !item.IsUploadType() {
 		item.UploadType = ""
 		item.AllowedFileType = nil
@@ -186,10 +259,26 @@ func (item *QuestionItem) IsCheckboxType() bool {
 	return item.Type == comm.QuestionTypeCheckbox || item.Type == comm.QuestionTypeVoteCheckbox
 }
 
+// SensitiveIDs 返回敏感信息题目ID列表
+func (s *SurveySchema) SensitiveIDs() []string {
+	return lo.FilterMap(s.QuestionConf.Items, func(item QuestionItem, _ int) (string, bool) {
+		return item.ID, item.IsSensitive()
+	})
+}
+
+// IsSensitive 是否为敏感信息题目 手机号及身份证号格式的输入题自动视为敏感信息
+func (item *QuestionItem) IsSensitive() bool {
+	return item.IsInputType() && (item.Sensitive || item.Valid == "m" || item.Valid == "idcard")
+}
+
 func (item *QuestionItem) IsUploadType() bool {
 	return item.Type == comm.QuestionTypeUpload
 }
 
+func (item *QuestionItem) IsCascadeType() bool {
+	return item.Type == comm.QuestionTypeCascade
+}
+
 func (item *QuestionItem) GetCategory() string {
 	if item.IsInputType() {
 		return "input"
@@ -200,5 +289,8 @@ func (item *QuestionItem) GetCategory() string {
 	if item.IsUploadType() {
 		return "upload"
 	}
+	if item.IsCascadeType() {
+		return "cascade"
+	}
 	return "unknown"
 }
@@ -0,0 +1,154 @@
+package schema
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// pipeRegex 答案引用占位符 如 {{q3}} 引用题目q3的答案
+var pipeRegex = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// PipeRefs 提取文本中引用的题目ID列表
+func PipeRefs(text string) []string {
+	if !strings.Contains(text, "{{") {
+		return nil
+	}
+	matches := pipeRegex.FindAllStringSubmatch(text, -1)
+	return lo.Uniq(lo.Map(matches, func(m []string, _ int) string {
+		return m[1]
+	}))
+}
+
+// HasPipe 题目是否包含答案引用
+func (item *QuestionItem) HasPipe() bool {
+	return len(item.pipeRefs()) > 0
+}
+
+func (item *QuestionItem) pipeRefs() []string {
+	refs := append(PipeRefs(item.Title), PipeRefs(item.Desc)...)
+	for _, text := range item.I18n {
+		refs = append(refs, PipeRefs(text.Title)...)
+		refs = append(refs, PipeRefs(text.Desc)...)
+	}
+	for _, opt := range item.Options {
+		refs = append(refs, PipeRefs(opt.Text)...)
+		for _, text := range opt.I18n {
+			refs = append(refs, PipeRefs(text.Text)...)
+		}
+	}
+	return lo.Uniq(refs)
+}
+
+// remapPipeRefs 按idMap替换题目文本中的答案引用 不在idMap中的引用保持不变
+func (item *QuestionItem) remapPipeRefs(idMap map[string]string) {
+	if !item.HasPipe() {
+		return
+	}
+	remap := func(text string) string {
+		return pipeRegex.ReplaceAllStringFunc(text, func(m string) string {
+			if id, ok := idMap[pipeRegex.FindStringSubmatch(m)[1]]; ok {
+				return "{{" + id + "}}"
+			}
+			return m
+		})
+	}
+
+	item.Title = remap(item.Title)
+	item.Desc = remap(item.Desc)
+	item.I18n = maps.Clone(item.I18n)
+	for locale, text := range item.I18n {
+		text.Title = remap(text.Title)
+		text.Desc = remap(text.Desc)
+		item.I18n[locale] = text
+	}
+	for j := range item.Options {
+		opt := &item.Options[j]
+		opt.Text = remap(opt.Text)
+		opt.I18n = maps.Clone(opt.I18n)
+		for locale, text := range opt.I18n {
+			text.Text = remap(text.Text)
+			opt.I18n[locale] = text
+		}
+	}
+}
+
+// verifyPipe 答案引用只能指向当前题目之前的题目
+func (s *SurveySchema) verifyPipe() error {
+	prevIDs := make(map[string]bool)
+	for i := range s.QuestionConf.Items {
+		item := &s.QuestionConf.Items[i]
+		for _, ref := range item.pipeRefs() {
+			if !prevIDs[ref] {
+				return fmt.Errorf("question(id=%s) error: pipe reference {{%s}} must point to a previous question", item.ID, ref)
+			}
+		}
+		prevIDs[item.ID] = true
+	}
+	return nil
+}
+
+// AnswerText 答案展示文本 选项类题目将选项ID转换为 (已渲染的) 选项文本 级联题转换为节点文本路径
+func (s *SurveySchema) AnswerText(item *QuestionItem, answer string, answers map[string]string) string {
+	if answer == "" {
+		return answer
+	}
+	if item.IsCascadeType() {
+		// 级联题将节点ID路径转换为节点文本路径
+		path, _ := item.CascadePath(answer)
+		return strings.Join(lo.Map(path, func(node CascadeNode, _ int) string {
+			return node.Text
+		}), "/")
+	}
+	if !item.IsOptionType() {
+		return answer
+	}
+	optMap := lo.KeyBy(item.Options, func(o Option) string {
+		return o.ID
+	})
+	texts := lo.FilterMap(strings.Split(answer, ","), func(id string, _ int) (string, bool) {
+		opt, ok := optMap[id]
+		if !ok {
+			return "", false
+		}
+		return s.RenderText(opt.Text, answers), true
+	})
+	return strings.Join(texts, ",")
+}
+
+// RenderText 将文本中的答案引用替换为对应题目的答案展示文本 未作答的引用替换为空
+func (s *SurveySchema) RenderText(text string, answers map[string]string) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+	itemMap := lo.KeyBy(s.QuestionConf.Items, func(item QuestionItem) string {
+		return item.ID
+	})
+	return pipeRegex.ReplaceAllStringFunc(text, func(m string) string {
+		id := pipeRegex.FindStringSubmatch(m)[1]
+		item, ok := itemMap[id]
+		if !ok {
+			return m
+		}
+		return s.AnswerText(&item, answers[id], answers)
+	})
+}
+
+// Render 按答卷内容渲染全部题目的标题、描述及选项文本
+func (s *SurveySchema) Render(answers map[string]string) {
+	for i := range s.QuestionConf.Items {
+		item := &s.QuestionConf.Items[i]
+		if !item.HasPipe() {
+			continue
+		}
+		// 引用只能指向之前的题目 被引用题目先行渲染后再次渲染结果不变
+		item.Title = s.RenderText(item.Title, answers)
+		item.Desc = s.RenderText(item.Desc, answers)
+		for j := range item.Options {
+			item.Options[j].Text = s.RenderText(item.Options[j].Text, answers)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"app/comm"
+)
+
+// StatsRef 答案计入的统计项
+type StatsRef struct {
+	QuestionID string `json:"question_id"`
+	OptionID   string `json:"option_id"`
+}
+
+// SelectedStats 计算答卷计入的统计项 与提交时的统计口径一致 用于删除答卷时扣减统计数据
+func (s *SurveySchema) SelectedStats(answerMap map[string]string) []StatsRef {
+	var refs []StatsRef
+	for _, item := range s.QuestionConf.Items {
+		val := answerMap[item.ID]
+		if val == "" {
+			continue
+		}
+		if item.IsOptionType() {
+			optIDs := lo.Map(item.Options, func(opt Option, _ int) string {
+				return opt.ID
+			})
+			for _, optID := range lo.Uniq(strings.Split(val, ",")) {
+				if slices.Contains(optIDs, optID) {
+					refs = append(refs, StatsRef{QuestionID: item.ID, OptionID: optID})
+				}
+			}
+		} else if item.IsCascadeType() {
+			path, ok := item.CascadePath(val)
+			if !ok {
+				continue
+			}
+			for _, node := range path {
+				refs = append(refs, StatsRef{QuestionID: item.ID, OptionID: node.ID})
+			}
+		}
+	}
+	return refs
+}
+
+// StripSensitive 移除敏感信息题目的答案 用于答卷匿名化
+func (s *SurveySchema) StripSensitive(items []comm.ResultItem) []comm.ResultItem {
+	sensitiveIDs := s.SensitiveIDs()
+	return lo.Reject(items, func(item comm.ResultItem, _ int) bool {
+		return slices.Contains(sensitiveIDs, item.QuestionID)
+	})
+}
+
+// RetentionDue 答卷是否已超过保留期限
+func (b *BaseConf) RetentionDue(now time.Time) bool {
+	if b.RetentionDays <= 0 || b.RetentionAction == "" {
+		return false
+	}
+	endTime, err := time.ParseInLocation(time.DateTime, b.EndTime, time.Local)
+	if err != nil {
+		return false
+	}
+	return now.After(endTime.AddDate(0, 0, b.RetentionDays))
+}
@@ -0,0 +1,188 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// QuestionGrade 单题判分结果
+type QuestionGrade struct {
+	QuestionID string
+	Answered   bool
+	Correct    bool
+	Score      int
+	FullScore  int
+}
+
+func (s *SurveySchema) IsQuiz() bool {
+	return s.QuizConf != nil
+}
+
+func (s *SurveySchema) verifyAndFixQuiz() error {
+	for i := range s.QuestionConf.Items {
+		item := &s.QuestionConf.Items[i]
+
+		// 非测验问卷及不可判分题型 清理测验配置
+		if !s.IsQuiz() || (!item.IsInputType() && !item.IsOptionType()) {
+			item.Score = 0
+			item.Answers = nil
+			for j := range item.Options {
+				item.Options[j].IsCorrect = false
+				item.Options[j].Score = 0
+			}
+			continue
+		}
+
+		if item.IsInputType() {
+			item.Answers = lo.Uniq(lo.FilterMap(item.Answers, func(answer string, _ int) (string, bool) {
+				answer = strings.TrimSpace(answer)
+				return answer, answer != ""
+			}))
+			if item.Score > 0 && len(item.Answers) == 0 {
+				return fmt.Errorf("question(id=%s) error: answers required when score is set", item.ID)
+			}
+			if len(item.Answers) == 0 {
+				item.Score = 0
+			}
+			continue
+		}
+
+		hasCorrect := lo.ContainsBy(item.Options, func(opt Option) bool {
+			return opt.IsCorrect
+		})
+		if item.Score > 0 {
+			// 整题计分 需指定正确选项 选项分值不生效
+			if !hasCorrect {
+				return fmt.Errorf("question(id=%s) error: correct option required when score is set", item.ID)
+			}
+			for j := range item.Options {
+				item.Options[j].Score = 0
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsGradable 题目是否参与测验判分
+func (item *QuestionItem) IsGradable() bool {
+	if item.IsInputType() {
+		return len(item.Answers) > 0
+	}
+	if item.IsOptionType() {
+		return item.Score > 0 || lo.ContainsBy(item.Options, func(opt Option) bool {
+			return opt.IsCorrect || opt.Score > 0
+		})
+	}
+	return false
+}
+
+// CorrectAnswers 题目正确答案 选项类题型为正确选项ID列表
+func (item *QuestionItem) CorrectAnswers() []string {
+	if item.IsInputType() {
+		return item.Answers
+	}
+	return lo.FilterMap(item.Options, func(opt Option, _ int) (string, bool) {
+		return opt.ID, opt.IsCorrect
+	})
+}
+
+// FullScore 题目满分
+func (item *QuestionItem) FullScore() int {
+	if !item.IsGradable() {
+		return 0
+	}
+	if item.Score > 0 || item.IsInputType() {
+		return item.Score
+	}
+
+	// 按选项分值累加 取可选数量内的最高分组合
+	scores := lo.Map(item.Options, func(opt Option, _ int) int {
+		return opt.Score
+	})
+	slices.SortFunc(scores, func(a, b int) int {
+		return b - a
+	})
+	limit := 1
+	if item.IsCheckboxType() {
+		limit = len(scores)
+		if item.MaxNum > 0 && item.MaxNum < limit {
+			limit = item.MaxNum
+		}
+	}
+	return lo.Sum(scores[:min(limit, len(scores))])
+}
+
+// Grade 单题判分 answer 为答卷中该题的原始答案
+func (item *QuestionItem) Grade(answer string) QuestionGrade {
+	grade := QuestionGrade{
+		QuestionID: item.ID,
+		Answered:   answer != "",
+		FullScore:  item.FullScore(),
+	}
+	if !grade.Answered || !item.IsGradable() {
+		return grade
+	}
+
+	if item.IsInputType() {
+		answer = strings.TrimSpace(answer)
+		grade.Correct = lo.ContainsBy(item.Answers, func(a string) bool {
+			return strings.EqualFold(a, answer)
+		})
+		if grade.Correct {
+			grade.Score = item.Score
+		}
+		return grade
+	}
+
+	selected := lo.Uniq(strings.Split(answer, ","))
+	correct := item.CorrectAnswers()
+	if len(correct) > 0 {
+		if item.IsCheckboxType() {
+			// 多选题须与正确选项完全一致
+			grade.Correct = len(selected) == len(correct) && lo.Every(correct, selected)
+		} else {
+			// 单选题命中任一正确选项即可
+			grade.Correct = len(selected) == 1 && lo.Contains(correct, selected[0])
+		}
+	}
+
+	if item.Score > 0 {
+		if grade.Correct {
+			grade.Score = item.Score
+		}
+		return grade
+	}
+
+	optScoreMap := lo.SliceToMap(item.Options, func(opt Option) (string, int) {
+		return opt.ID, opt.Score
+	})
+	grade.Score = lo.SumBy(selected, func(id string) int {
+		return optScoreMap[id]
+	})
+	if len(correct) == 0 {
+		grade.Correct = grade.FullScore > 0 && grade.Score >= grade.FullScore
+	}
+	return grade
+}
+
+// FullScore 测验总分
+func (s *SurveySchema) FullScore() int {
+	return lo.SumBy(s.QuestionConf.Items, func(item QuestionItem) int {
+		return item.FullScore()
+	})
+}
+
+// Grade 按测验配置对答卷判分 返回总得分及参与判分题目的判分结果
+func (s *SurveySchema) Grade(answers map[string]string) (int, []QuestionGrade) {
+	grades := lo.FilterMap(s.QuestionConf.Items, func(item QuestionItem, _ int) (QuestionGrade, bool) {
+		return item.Grade(answers[item.ID]), item.IsGradable()
+	})
+	score := lo.SumBy(grades, func(g QuestionGrade) int {
+		return g.Score
+	})
+	return score, grades
+}
@@ -0,0 +1,240 @@
+package schema
+
+import (
+	"testing"
+
+	"app/comm"
+)
+
+func quizCheckbox(score int, options ...Option) QuestionItem {
+	return QuestionItem{ID: "q1", Type: comm.QuestionTypeCheckbox, Title: "多选", MaxNum: len(options), Score: score, Options: options}
+}
+
+func TestQuestionItemGrade(t *testing.T) {
+	tests := []struct {
+		name   string
+		item   QuestionItem
+		answer string
+		want   QuestionGrade
+	}{
+		{
+			name: "多选题整题计分 完全一致得分",
+			item: quizCheckbox(5,
+				Option{ID: "a", Text: "A", IsCorrect: true},
+				Option{ID: "b", Text: "B", IsCorrect: true},
+				Option{ID: "c", Text: "C"},
+			),
+			answer: "b,a",
+			want:   QuestionGrade{QuestionID: "q1", Answered: true, Correct: true, Score: 5, FullScore: 5},
+		},
+		{
+			name: "多选题整题计分 漏选不得分",
+			item: quizCheckbox(5,
+				Option{ID: "a", Text: "A", IsCorrect: true},
+				Option{ID: "b", Text: "B", IsCorrect: true},
+				Option{ID: "c", Text: "C"},
+			),
+			answer: "a",
+			want:   QuestionGrade{QuestionID: "q1", Answered: true, Correct: false, Score: 0, FullScore: 5},
+		},
+		{
+			name: "多选题整题计分 多选不得分",
+			item: quizCheckbox(5,
+				Option{ID: "a", Text: "A", IsCorrect: true},
+				Option{ID: "b", Text: "B", IsCorrect: true},
+				Option{ID: "c", Text: "C"},
+			),
+			answer: "a,b,c",
+			want:   QuestionGrade{QuestionID: "q1", Answered: true, Correct: false, Score: 0, FullScore: 5},
+		},
+		{
+			name: "多选题选项计分 部分得分",
+			item: quizCheckbox(0,
+				Option{ID: "a", Text: "A", Score: 2},
+				Option{ID: "b", Text: "B", Score: 3},
+				Option{ID: "c", Text: "C"},
+			),
+			answer: "a,c",
+			want:   QuestionGrade{QuestionID: "q1", Answered: true, Correct: false, Score: 2, FullScore: 5},
+		},
+		{
+			name: "多选题选项计分 满分判对",
+			item: quizCheckbox(0,
+				Option{ID: "a", Text: "A", Score: 2},
+				Option{ID: "b", Text: "B", Score: 3},
+				Option{ID: "c", Text: "C"},
+			),
+			answer: "a,b,a",
+			want:   QuestionGrade{QuestionID: "q1", Answered: true, Correct: true, Score: 5, FullScore: 5},
+		},
+		{
+			name: "单选题命中任一正确选项",
+			item: QuestionItem{ID: "q1", Type: comm.QuestionTypeRadio, Score: 2, Options: []Option{
+				{ID: "a", Text: "A", IsCorrect: true},
+				{ID: "b", Text: "B", IsCorrect: true},
+				{ID: "c", Text: "C"},
+			}},
+			answer: "b",
+			want:   QuestionGrade{QuestionID: "q1", Answered: true, Correct: true, Score: 2, FullScore: 2},
+		},
+		{
+			name:   "输入题忽略首尾空白及大小写",
+			item:   QuestionItem{ID: "q1", Type: comm.QuestionTypeText, Valid: "*", Score: 3, Answers: []string{"Go"}},
+			answer: "  gO ",
+			want:   QuestionGrade{QuestionID: "q1", Answered: true, Correct: true, Score: 3, FullScore: 3},
+		},
+		{
+			name:   "输入题答案错误",
+			item:   QuestionItem{ID: "q1", Type: comm.QuestionTypeText, Valid: "*", Score: 3, Answers: []string{"Go"}},
+			answer: "Golang",
+			want:   QuestionGrade{QuestionID: "q1", Answered: true, Correct: false, Score: 0, FullScore: 3},
+		},
+		{
+			name: "未作答",
+			item: quizCheckbox(5,
+				Option{ID: "a", Text: "A", IsCorrect: true},
+				Option{ID: "b", Text: "B"},
+			),
+			answer: "",
+			want:   QuestionGrade{QuestionID: "q1", Answered: false, Correct: false, Score: 0, FullScore: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.item.Grade(tt.answer)
+			if got != tt.want {
+				t.Errorf("Grade(%q) = %+v, want %+v", tt.answer, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuestionItemFullScore(t *testing.T) {
+	tests := []struct {
+		name string
+		item QuestionItem
+		want int
+	}{
+		{
+			name: "整题计分",
+			item: quizCheckbox(4, Option{ID: "a", Text: "A", IsCorrect: true}, Option{ID: "b", Text: "B"}),
+			want: 4,
+		},
+		{
+			name: "多选题选项计分 受最多选择数限制",
+			item: QuestionItem{ID: "q1", Type: comm.QuestionTypeCheckbox, MaxNum: 2, Options: []Option{
+				{ID: "a", Text: "A", Score: 1},
+				{ID: "b", Text: "B", Score: 4},
+				{ID: "c", Text: "C", Score: 2},
+			}},
+			want: 6,
+		},
+		{
+			name: "单选题选项计分 取最高分",
+			item: QuestionItem{ID: "q1", Type: comm.QuestionTypeRadio, Options: []Option{
+				{ID: "a", Text: "A", Score: 1},
+				{ID: "b", Text: "B", Score: 3},
+			}},
+			want: 3,
+		},
+		{
+			name: "不参与判分",
+			item: QuestionItem{ID: "q1", Type: comm.QuestionTypeRadio, Options: []Option{{ID: "a", Text: "A"}}},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.FullScore(); got != tt.want {
+				t.Errorf("FullScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSurveySchemaGrade(t *testing.T) {
+	s := &SurveySchema{
+		QuizConf: &QuizConf{},
+		QuestionConf: QuestionConf{Items: []QuestionItem{
+			quizCheckbox(5, Option{ID: "a", Text: "A", IsCorrect: true}, Option{ID: "b", Text: "B"}),
+			{ID: "q2", Type: comm.QuestionTypeText, Valid: "*", Score: 3, Answers: []string{"yes"}},
+			{ID: "q3", Type: comm.QuestionTypeRadio, Options: []Option{{ID: "a", Text: "A", Score: 2}, {ID: "b", Text: "B", Score: 1}}},
+			{ID: "q4", Type: comm.QuestionTypeTextArea, Valid: "*"},
+		}},
+	}
+
+	if got := s.FullScore(); got != 10 {
+		t.Fatalf("FullScore() = %d, want 10", got)
+	}
+
+	score, grades := s.Grade(map[string]string{"q1": "a", "q3": "b", "q4": "不参与判分"})
+	if score != 6 {
+		t.Errorf("Grade() score = %d, want 6", score)
+	}
+	if len(grades) != 3 {
+		t.Fatalf("Grade() returned %d grades, want 3", len(grades))
+	}
+	if grades[1].QuestionID != "q2" || grades[1].Answered {
+		t.Errorf("Grade() unanswered q2 = %+v", grades[1])
+	}
+}
+
+func TestVerifyAndFixQuiz(t *testing.T) {
+	t.Run("输入题答案去除空白及重复", func(t *testing.T) {
+		s := &SurveySchema{QuizConf: &QuizConf{}, QuestionConf: QuestionConf{Items: []QuestionItem{
+			{ID: "q1", Type: comm.QuestionTypeText, Score: 2, Answers: []string{" a ", "a", "", "b"}},
+		}}}
+		if err := s.verifyAndFixQuiz(); err != nil {
+			t.Fatalf("verifyAndFixQuiz() error = %v", err)
+		}
+		got := s.QuestionConf.Items[0].Answers
+		if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+			t.Errorf("Answers = %q, want [a b]", got)
+		}
+	})
+
+	t.Run("输入题设置分值须指定答案", func(t *testing.T) {
+		s := &SurveySchema{QuizConf: &QuizConf{}, QuestionConf: QuestionConf{Items: []QuestionItem{
+			{ID: "q1", Type: comm.QuestionTypeText, Score: 2, Answers: []string{" "}},
+		}}}
+		if err := s.verifyAndFixQuiz(); err == nil {
+			t.Error("verifyAndFixQuiz() error = nil, want error")
+		}
+	})
+
+	t.Run("整题计分须指定正确选项", func(t *testing.T) {
+		s := &SurveySchema{QuizConf: &QuizConf{}, QuestionConf: QuestionConf{Items: []QuestionItem{
+			quizCheckbox(2, Option{ID: "a", Text: "A"}),
+		}}}
+		if err := s.verifyAndFixQuiz(); err == nil {
+			t.Error("verifyAndFixQuiz() error = nil, want error")
+		}
+	})
+
+	t.Run("整题计分清除选项分值", func(t *testing.T) {
+		s := &SurveySchema{QuizConf: &QuizConf{}, QuestionConf: QuestionConf{Items: []QuestionItem{
+			quizCheckbox(2, Option{ID: "a", Text: "A", IsCorrect: true, Score: 3}),
+		}}}
+		if err := s.verifyAndFixQuiz(); err != nil {
+			t.Fatalf("verifyAndFixQuiz() error = %v", err)
+		}
+		if got := s.QuestionConf.Items[0].Options[0].Score; got != 0 {
+			t.Errorf("option score = %d, want 0", got)
+		}
+	})
+
+	t.Run("非测验问卷清除测验配置", func(t *testing.T) {
+		s := &SurveySchema{QuestionConf: QuestionConf{Items: []QuestionItem{
+			quizCheckbox(2, Option{ID: "a", Text: "A", IsCorrect: true, Score: 3}),
+		}}}
+		if err := s.verifyAndFixQuiz(); err != nil {
+			t.Fatalf("verifyAndFixQuiz() error = %v", err)
+		}
+		item := s.QuestionConf.Items[0]
+		if item.Score != 0 || item.Options[0].IsCorrect || item.Options[0].Score != 0 {
+			t.Errorf("quiz config not cleared: %+v", item)
+		}
+	})
+}